@@ -2,6 +2,7 @@ package bean
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 )
 
@@ -9,16 +10,53 @@ import (
 // @source 源对象
 // @fields 复制的字段，默认复制所有相同的字段
 func CopyTo[E interface{}](source interface{}, fields ...string) (E, error) {
+	return CopyToWithOptions[E](source, WithFields(fields...))
+}
+
+// CopyToWithOptions 按配置将源对象转为泛型指定的目标对象
+// @source 源对象
+// @opts 复制的配置
+func CopyToWithOptions[E interface{}](source interface{}, opts ...Option) (E, error) {
 	var target E
-	err := Copy(source, &target, fields...)
+	err := CopyWithOptions(source, &target, opts...)
 	return target, err
 }
 
-// Copy 源对象转为目标对象
+// Copy 源对象转为目标对象，嵌套的结构体、指针、切片、数组与映射会按字段名递归复制，
+// 源对象中的循环引用在目标对象中保持相同的引用关系
 // @source 源对象
 // @target 目标对象
-// @fields 复制的字段，默认复制所有相同的字段
+// @fields 复制的字段，默认复制所有相同的字段（仅作用于最外层的结构体）
 func Copy(source, target interface{}, fields ...string) error {
+	return CopyWithOptions(source, target, WithFields(fields...))
+}
+
+// CopyWithOptions 按配置将源对象转为目标对象。字段按映射名称匹配，映射名称默认为字段名称，
+// 可通过标签修改，如 `bean:"name=UserName"`；标记为 `bean:"ignore"` 或 `bean:"-"` 的字段不复制，
+// 标记为 `bean:"omitempty"` 的字段为零值时不复制
+// @source 源对象
+// @target 目标对象
+// @opts 复制的配置
+func CopyWithOptions(source, target interface{}, opts ...Option) error {
+	return copyWithReporter(source, target, newOptions(opts...), nil)
+}
+
+// copyWithReporter 按配置复制对象，r 不为空或严格模式时收集诊断信息
+func copyWithReporter(source, target interface{}, o *options, r *reporter) error {
+	if r == nil && o.strict {
+		r = newReporter()
+	}
+	if err := copyAny(source, target, o, r); err != nil {
+		return err
+	}
+	if o.strict && r.report.HasIssues() {
+		return fmt.Errorf("%w: %s", ErrStrictCopy, r.report.String())
+	}
+	return nil
+}
+
+// copyAny 复制结构体或切片
+func copyAny(source, target interface{}, o *options, r *reporter) error {
 	sourceValue := reflect.ValueOf(source)
 	if !sourceValue.IsValid() {
 		return errors.New("source value invalid")
@@ -33,35 +71,30 @@ func Copy(source, target interface{}, fields ...string) error {
 	}
 	switch sourceValue.Type().Kind() {
 	case reflect.Array, reflect.Slice:
-		if sourceValue.IsNil() {
+		if sourceValue.Kind() == reflect.Slice && sourceValue.IsNil() {
 			return nil
 		}
 		targetValue := reflect.ValueOf(target)
 		if targetValue.Kind() != reflect.Ptr {
 			return errors.New("target value can't a pointer type")
 		}
-		for targetValue.Kind() == reflect.Ptr {
-			//if targetValue.IsNil() && targetValue.CanSet() {
-			//	targetValue.Set(reflect.New(targetValue.Type().Elem()))
-			//}
-			targetValue = targetValue.Elem()
+		targetValue = NewPointer(targetValue)
+		if targetValue.Kind() != reflect.Slice {
+			return errors.New("target value must be a slice")
 		}
 		// 切片中项的类型
 		targetItemType := targetValue.Type().Elem()
-		isPointer := targetItemType.Kind() == reflect.Ptr
-		if isPointer {
-			targetItemType = targetItemType.Elem()
-		}
-		var targetValueSlice []reflect.Value
+		c := newCopier(o)
+		c.reporter = r
+		targetValueSlice := make([]reflect.Value, 0, sourceValue.Len())
 		for i := 0; i < sourceValue.Len(); i++ {
+			targetItemValue := reflect.New(targetItemType).Elem()
 			sourceItemValue := sourceValue.Index(i)
-			//if sourceItemValue.Kind() != reflect.Ptr {
-			//	sourceItemValue = sourceItemValue.Addr()
-			//}
-			targetItemValue := reflect.New(targetItemType)
-			copyObj(sourceItemValue.Interface(), targetItemValue.Interface(), fields...)
-			if !isPointer {
-				targetItemValue = targetItemValue.Elem()
+			// 源切片中的 nil 项在目标切片中保持零值
+			if !isNilValue(sourceItemValue) {
+				if err := c.copyItem(targetItemValue, sourceItemValue); err != nil {
+					return fmt.Errorf("copy index %d: %w", i, err)
+				}
 			}
 			targetValueSlice = append(targetValueSlice, targetItemValue)
 		}
@@ -71,73 +104,69 @@ func Copy(source, target interface{}, fields ...string) error {
 			targetValue.Set(reflect.MakeSlice(targetValue.Type(), 0, 0))
 		}
 	case reflect.Struct:
-		copyObj(sourceValue.Interface(), target, fields...)
+		return copyObjWithOptions(source, target, o, r)
 	default:
 		return errors.New("source type invalid")
 	}
 	return nil
 }
 
+// isNilValue 判断指针或接口是否为 nil
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
 // copyObj 复制对象
 func copyObj(source, target interface{}, fields ...string) error {
+	return copyObjWithOptions(source, target, newOptions(WithFields(fields...)), nil)
+}
+
+// copyObjWithOptions 按配置复制对象
+func copyObjWithOptions(source, target interface{}, o *options, r *reporter) error {
 	sourceValue := reflect.ValueOf(source)
 	targetValue := reflect.ValueOf(target)
 	if !sourceValue.IsValid() {
 		return errors.New("source value invalid")
 	}
-	if sourceValue.Kind() == reflect.Ptr {
-		if sourceValue.IsNil() {
-			return errors.New("source value can't nil")
-		}
-		for sourceValue.Kind() == reflect.Ptr {
-			sourceValue = sourceValue.Elem()
-		}
-	}
 	if targetValue.Kind() != reflect.Ptr {
 		return errors.New("target value can't a pointer type")
 	}
 	if targetValue.IsNil() {
 		return errors.New("target value can't be nil")
 	}
-	targetValue = NewPointer(targetValue)
-	// 获取设置的属性列表
-	hasFieldAll := len(fields) == 0 // 不设置默认全部字段
-	fieldMap := make(map[string]struct{}, len(fields))
-	for _, fieldName := range fields {
-		fieldMap[fieldName] = struct{}{}
-	}
-	for i := 0; i < sourceValue.NumField(); i++ {
-		fieldName := sourceValue.Type().Field(i).Name
-		hasField := hasFieldAll
-		if !hasFieldAll {
-			if _, found := fieldMap[fieldName]; found {
-				hasField = true
-			}
-		}
-		if !hasField {
-			continue
-		}
-		targetFieldValue := targetValue.FieldByName(fieldName)
-		if !targetFieldValue.IsValid() {
-			continue
+	c := newCopier(o)
+	c.reporter = r
+	return c.copyItem(NewPointer(targetValue), sourceValue)
+}
+
+// copyItem 将源对象复制到可设置的目标对象，源对象与目标对象可以是任意层级的指针
+func (c *copier) copyItem(targetValue, sourceValue reflect.Value) error {
+	if sourceValue.Kind() == reflect.Ptr {
+		if sourceValue.IsNil() {
+			return errors.New("source value can't nil")
 		}
-		sourceFieldValue := sourceValue.FieldByName(fieldName)
-		if targetFieldValue.Kind() == sourceFieldValue.Kind() {
-			fieldValue := reflect.ValueOf(sourceFieldValue.Interface())
-			targetFieldValue.Set(fieldValue)
-		} else if targetFieldValue.Kind() != reflect.Ptr && sourceFieldValue.Kind() == reflect.Ptr {
-			if !sourceFieldValue.IsNil() {
-				sourceFieldValue = sourceFieldValue.Elem()
-				fieldValue := reflect.ValueOf(sourceFieldValue.Interface())
-				targetFieldValue.Set(fieldValue)
+		for sourceValue.Kind() == reflect.Ptr {
+			// 记录最外层对象的指针，循环引用回到最外层对象时复用目标对象
+			if sourceValue.Elem().Kind() == reflect.Struct {
+				targetStruct := NewPointer(targetValue)
+				if targetStruct.CanAddr() {
+					c.visited[visit{ptr: sourceValue.Pointer(), typ: targetStruct.Addr().Type()}] = targetStruct.Addr()
+				}
 			}
-		} else if targetFieldValue.Kind() == reflect.Ptr && sourceFieldValue.Kind() != reflect.Ptr {
-			targetFieldValue = NewPointer(targetFieldValue)
-			fieldValue := reflect.ValueOf(sourceFieldValue.Interface())
-			targetFieldValue.Set(fieldValue)
+			sourceValue = sourceValue.Elem()
 		}
 	}
-	return nil
+	targetValue = NewPointer(targetValue)
+	if sourceValue.Kind() != reflect.Struct || targetValue.Kind() != reflect.Struct {
+		_, err := c.copyValue(targetValue, sourceValue)
+		return err
+	}
+	return c.copyStruct(targetValue, sourceValue, c.options)
 }
 
 func NewPointer(targetFieldValue reflect.Value) reflect.Value {
@@ -0,0 +1,395 @@
+// beangen 为声明的类型对生成直接赋值的复制函数，用于替代热点路径上基于反射的 bean.Copy。
+// 字段按与 bean.Copy 相同的规则匹配（bean 标签的 name、ignore、omitempty），
+// 只生成类型完全相同的字段赋值，其他字段以注释说明原因。omitempty 字段与零值直接比较，
+// 只有无法从源码判断能否比较的类型（如其他包中的结构体）才使用 reflect。
+//
+// 与 bean.Copy 的区别：切片、映射与指针字段只复制一层（slices.Clone、maps.Clone 与复制指针指向的值），
+// 其中的元素以及结构体、数组字段内部的引用仍按赋值共享，而 bean.Copy 会递归深拷贝。
+//
+// 用法：
+//
+//	//go:generate go run github.com/minlib/go-util/bean/cmd/beangen -pairs User:UserDTO,Order:OrderDTO
+//
+// 会在当前目录生成 bean_copy_gen.go，包含 CopyUserToUserDTO 与 CopyUserSliceToUserDTO 等函数
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	pairs := flag.String("pairs", "", "type pairs to generate, such as User:UserDTO,Order:OrderDTO")
+	output := flag.String("output", "bean_copy_gen.go", "output file name")
+	dir := flag.String("dir", ".", "package directory")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("beangen: ")
+
+	typePairs, err := parsePairs(*pairs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(*dir, *output, typePairs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// typePair 源类型与目标类型
+type typePair struct {
+	src string
+	dst string
+}
+
+// parsePairs 解析 Src:Dst 格式的类型对列表
+func parsePairs(s string) ([]typePair, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("-pairs is required")
+	}
+	var pairs []typePair
+	for _, item := range strings.Split(s, ",") {
+		src, dst, found := strings.Cut(strings.TrimSpace(item), ":")
+		if !found || !token.IsIdentifier(src) || !token.IsIdentifier(dst) {
+			return nil, fmt.Errorf("invalid type pair %q, want Src:Dst", item)
+		}
+		pairs = append(pairs, typePair{src: src, dst: dst})
+	}
+	return pairs, nil
+}
+
+// structField 结构体字段
+type structField struct {
+	name      string   // 字段名称
+	mapping   string   // 映射名称
+	typ       string   // 字段类型的源码
+	expr      ast.Expr // 字段类型的语法树
+	ignore    bool     // 不参与复制
+	omitEmpty bool     // 零值时不复制
+}
+
+// generate 解析目录下的包并生成复制函数的源码
+func generate(dir, output string, pairs []typePair) ([]byte, error) {
+	pkgName, structs, specs, err := parseStructs(dir, output)
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	imports := make(map[string]bool)
+	for _, pair := range pairs {
+		srcFields, found := structs[pair.src]
+		if !found {
+			return nil, fmt.Errorf("struct %s not found in %s", pair.src, dir)
+		}
+		dstFields, found := structs[pair.dst]
+		if !found {
+			return nil, fmt.Errorf("struct %s not found in %s", pair.dst, dir)
+		}
+		writeCopyFunc(&body, pair, srcFields, dstFields, specs, imports)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by beangen. DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, strconv.Quote(path))
+		}
+		sort.Strings(paths)
+		fmt.Fprintf(&buf, "import (\n%s\n)\n\n", strings.Join(paths, "\n"))
+	}
+	buf.Write(body.Bytes())
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// parseStructs 解析目录下非测试文件中的结构体定义，同时返回包内所有非泛型类型的定义
+func parseStructs(dir, output string) (string, map[string][]structField, map[string]ast.Expr, error) {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	sort.Strings(filenames)
+	fset := token.NewFileSet()
+	pkgName := ""
+	structs := make(map[string][]structField)
+	specs := make(map[string]ast.Expr)
+	for _, filename := range filenames {
+		base := filepath.Base(filename)
+		if strings.HasSuffix(base, "_test.go") || base == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filename, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		pkgName = file.Name.Name
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.TypeSpec)
+			if !ok || spec.TypeParams != nil {
+				return true
+			}
+			specs[spec.Name.Name] = spec.Type
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				structs[spec.Name.Name] = structFields(fset, st)
+			}
+			return true
+		})
+	}
+	if pkgName == "" {
+		return "", nil, nil, fmt.Errorf("no go files found in %s", dir)
+	}
+	return pkgName, structs, specs, nil
+}
+
+// structFields 获取结构体中导出的非嵌入字段
+func structFields(fset *token.FileSet, st *ast.StructType) []structField {
+	var fields []structField
+	for _, field := range st.Fields.List {
+		var typ bytes.Buffer
+		_ = printer.Fprint(&typ, fset, field.Type)
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			value, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			f := structField{name: name.Name, mapping: name.Name, typ: typ.String(), expr: field.Type}
+			parseTag(&f, tag)
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// parseTag 解析 bean 标签，规则与 bean 包一致
+func parseTag(f *structField, tag reflect.StructTag) {
+	value, found := tag.Lookup("bean")
+	if !found {
+		return
+	}
+	if value == "-" {
+		f.ignore = true
+		return
+	}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "ignore":
+			f.ignore = true
+		case part == "omitempty":
+			f.omitEmpty = true
+		case strings.HasPrefix(part, "name="):
+			if name := strings.TrimSpace(strings.TrimPrefix(part, "name=")); name != "" {
+				f.mapping = name
+			}
+		}
+	}
+}
+
+// writeCopyFunc 生成单个类型对的复制函数，使用的包记录到 imports
+func writeCopyFunc(w *bytes.Buffer, pair typePair, srcFields, dstFields []structField, specs map[string]ast.Expr, imports map[string]bool) {
+	dstByMapping := make(map[string]structField, len(dstFields))
+	for _, f := range dstFields {
+		if !f.ignore {
+			dstByMapping[f.mapping] = f
+		}
+	}
+	name := "Copy" + pair.src + "To" + pair.dst
+	fmt.Fprintf(w, "// %s 将 %s 复制到 %s\n", name, pair.src, pair.dst)
+	fmt.Fprintf(w, "func %s(src *%s, dst *%s) {\n", name, pair.src, pair.dst)
+	for _, sf := range srcFields {
+		if sf.ignore {
+			continue
+		}
+		df, found := dstByMapping[sf.mapping]
+		if !found {
+			continue
+		}
+		if sf.typ != df.typ {
+			fmt.Fprintf(w, "// %s: %s is not assignable to %s, skipped\n", sf.name, sf.typ, df.typ)
+			continue
+		}
+		kind := referenceKind(sf.expr, specs, nil)
+		if sf.omitEmpty || df.omitEmpty {
+			var cond string
+			switch zero := zeroValue(sf.expr, sf.typ, specs); zero {
+			case "":
+				imports["reflect"] = true
+				cond = fmt.Sprintf("!reflect.ValueOf(src.%s).IsZero()", sf.name)
+			case "false":
+				cond = "src." + sf.name
+			default:
+				cond = fmt.Sprintf("src.%s != %s", sf.name, zero)
+			}
+			fmt.Fprintf(w, "if %s {\n", cond)
+			if kind == "pointer" {
+				// 条件已保证指针不为 nil
+				fmt.Fprintf(w, "v := *src.%s\ndst.%s = &v\n", sf.name, df.name)
+			} else {
+				writeAssign(w, df.name, sf.name, kind, imports)
+			}
+			w.WriteString("}\n")
+			continue
+		}
+		writeAssign(w, df.name, sf.name, kind, imports)
+	}
+	w.WriteString("}\n\n")
+
+	sliceName := "Copy" + pair.src + "SliceTo" + pair.dst
+	fmt.Fprintf(w, "// %s 将 %s 切片复制为 %s 切片\n", sliceName, pair.src, pair.dst)
+	fmt.Fprintf(w, "func %s(src []%s) []%s {\n", sliceName, pair.src, pair.dst)
+	fmt.Fprintf(w, "if src == nil {\nreturn nil\n}\n")
+	fmt.Fprintf(w, "dst := make([]%s, len(src))\n", pair.dst)
+	fmt.Fprintf(w, "for i := range src {\n%s(&src[i], &dst[i])\n}\n", name)
+	w.WriteString("return dst\n}\n\n")
+}
+
+// writeAssign 生成字段赋值，切片、映射与指针复制一层，不与源对象共享
+// @kind referenceKind 返回的引用类型
+func writeAssign(w *bytes.Buffer, dstName, srcName, kind string, imports map[string]bool) {
+	switch kind {
+	case "slice":
+		imports["slices"] = true
+		fmt.Fprintf(w, "dst.%s = slices.Clone(src.%s)\n", dstName, srcName)
+	case "map":
+		imports["maps"] = true
+		fmt.Fprintf(w, "dst.%s = maps.Clone(src.%s)\n", dstName, srcName)
+	case "pointer":
+		fmt.Fprintf(w, "if src.%s != nil {\nv := *src.%s\ndst.%s = &v\n} else {\ndst.%s = nil\n}\n", srcName, srcName, dstName, dstName)
+	default:
+		fmt.Fprintf(w, "dst.%s = src.%s\n", dstName, srcName)
+	}
+}
+
+// referenceKind 返回需要复制一层的引用类型：slice、map 或 pointer，包内定义的类型按底层类型判断，其他类型返回空字符串
+func referenceKind(expr ast.Expr, specs map[string]ast.Expr, seen map[string]bool) string {
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		return referenceKind(t.X, specs, seen)
+	case *ast.StarExpr:
+		return "pointer"
+	case *ast.MapType:
+		return "map"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "slice"
+		}
+	case *ast.Ident:
+		spec, found := specs[t.Name]
+		if !found || seen[t.Name] {
+			return ""
+		}
+		if seen == nil {
+			seen = make(map[string]bool)
+		}
+		seen[t.Name] = true
+		return referenceKind(spec, specs, seen)
+	}
+	return ""
+}
+
+// numericTypes 内置的数值类型
+var numericTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true, "byte": true, "rune": true,
+}
+
+// zeroValue 返回用于与字段直接比较的零值表达式，引用类型与 nil 比较，可比较的数组与结构体与 typ{} 比较。
+// 无法从源码判断能否比较时（如其他包中的类型）返回空字符串
+// @expr 字段类型的语法树
+// @typ 字段类型的源码，用于生成复合字面量
+// @specs 包内的类型定义
+func zeroValue(expr ast.Expr, typ string, specs map[string]ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		return zeroValue(t.X, typ, specs)
+	case *ast.StarExpr, *ast.MapType, *ast.FuncType, *ast.ChanType, *ast.InterfaceType:
+		return "nil"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil"
+		}
+		if isComparable(t, specs, nil) {
+			return "(" + typ + "{})"
+		}
+	case *ast.StructType:
+		if isComparable(t, specs, nil) {
+			return "(" + typ + "{})"
+		}
+	case *ast.Ident:
+		switch {
+		case numericTypes[t.Name]:
+			return "0"
+		case t.Name == "string":
+			return `""`
+		case t.Name == "bool":
+			return "false"
+		case t.Name == "any" || t.Name == "error":
+			return "nil"
+		case specs[t.Name] != nil:
+			// 包内定义的类型按底层类型处理，复合字面量仍使用字段的类型
+			return zeroValue(specs[t.Name], typ, specs)
+		}
+	}
+	return ""
+}
+
+// isComparable 判断类型能否使用 == 比较而不会 panic，无法从源码判断时返回 false。
+// 接口的动态值可能不可比较（如切片或映射），包含接口的结构体与数组视为不可比较
+func isComparable(expr ast.Expr, specs map[string]ast.Expr, seen map[string]bool) bool {
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		return isComparable(t.X, specs, seen)
+	case *ast.StarExpr, *ast.ChanType:
+		return true
+	case *ast.ArrayType:
+		return t.Len != nil && isComparable(t.Elt, specs, seen)
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if !isComparable(field.Type, specs, seen) {
+				return false
+			}
+		}
+		return true
+	case *ast.Ident:
+		if numericTypes[t.Name] || t.Name == "string" || t.Name == "bool" {
+			return true
+		}
+		spec, found := specs[t.Name]
+		if !found || seen[t.Name] {
+			return false
+		}
+		if seen == nil {
+			seen = make(map[string]bool)
+		}
+		seen[t.Name] = true
+		return isComparable(spec, specs, seen)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSource = `package model
+
+import "time"
+
+type Status int
+
+type Address struct {
+	City   string
+	Parent *Address
+}
+
+type Profile struct {
+	Tags []string
+}
+
+type Shape interface {
+	Area() float64
+}
+
+type Option struct {
+	Name  string
+	Value any
+}
+
+type Layout struct {
+	Shape Shape
+}
+
+type Labels map[string]string
+
+type User struct {
+	Id        int64
+	Name      string     ` + "`bean:\"name=UserName\"`" + `
+	Password  string     ` + "`bean:\"-\"`" + `
+	Nickname  string     ` + "`bean:\"omitempty\"`" + `
+	Age       int
+	Score     float64    ` + "`bean:\"omitempty\"`" + `
+	Active    bool       ` + "`bean:\"omitempty\"`" + `
+	Status    Status     ` + "`bean:\"omitempty\"`" + `
+	Roles     []string   ` + "`bean:\"omitempty\"`" + `
+	Manager   *User      ` + "`bean:\"omitempty\"`" + `
+	Address   Address    ` + "`bean:\"omitempty\"`" + `
+	Profile   Profile    ` + "`bean:\"omitempty\"`" + `
+	CreatedAt time.Time  ` + "`bean:\"omitempty\"`" + `
+	Option    Option     ` + "`bean:\"omitempty\"`" + `
+	Layout    Layout     ` + "`bean:\"omitempty\"`" + `
+	Extra     any        ` + "`bean:\"omitempty\"`" + `
+	Tags      []string
+	Labels    Labels
+	Leader    *User
+	password  string
+}
+
+type UserDTO struct {
+	Id        int64
+	UserName  string
+	Password  string
+	Nickname  string
+	Age       string
+	Score     float64
+	Active    bool
+	Status    Status
+	Roles     []string
+	Manager   *User
+	Address   Address
+	Profile   Profile
+	CreatedAt time.Time
+	Option    Option
+	Layout    Layout
+	Extra     any
+	Tags      []string
+	Labels    Labels
+	Leader    *User
+}
+`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "model.go"), []byte(testSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pairs, err := parsePairs("User:UserDTO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(dir, "bean_copy_gen.go", pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := string(src)
+	for _, want := range []string{
+		"// Code generated by beangen. DO NOT EDIT.",
+		"package model",
+		"import (\n\t\"maps\"\n\t\"reflect\"\n\t\"slices\"\n)",
+		"func CopyUserToUserDTO(src *User, dst *UserDTO) {",
+		"\tdst.Id = src.Id\n",
+		"\tdst.UserName = src.Name\n",
+		"\tif src.Nickname != \"\" {",
+		"\tif src.Score != 0 {",
+		"\tif src.Active {",
+		"\tif src.Status != 0 {",
+		"\tif src.Roles != nil {\n\t\tdst.Roles = slices.Clone(src.Roles)\n\t}",
+		"\tif src.Manager != nil {\n\t\tv := *src.Manager\n\t\tdst.Manager = &v\n\t}",
+		"\tif src.Address != (Address{}) {",
+		// 包含切片的结构体不能比较，其他包中的类型无法判断，使用 reflect
+		"\tif !reflect.ValueOf(src.Profile).IsZero() {",
+		"\tif !reflect.ValueOf(src.CreatedAt).IsZero() {",
+		// 包含接口的结构体比较时可能 panic，使用 reflect
+		"\tif !reflect.ValueOf(src.Option).IsZero() {",
+		"\tif !reflect.ValueOf(src.Layout).IsZero() {",
+		"\tif src.Extra != nil {",
+		"\t// Age: int is not assignable to string, skipped\n",
+		// 切片、映射与指针复制一层，不与源对象共享
+		"\tdst.Tags = slices.Clone(src.Tags)\n",
+		"\tdst.Labels = maps.Clone(src.Labels)\n",
+		"\tif src.Leader != nil {\n\t\tv := *src.Leader\n\t\tdst.Leader = &v\n\t} else {\n\t\tdst.Leader = nil\n\t}",
+		"func CopyUserSliceToUserDTO(src []User) []UserDTO {",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "Password") {
+		t.Errorf("ignored field was generated:\n%s", code)
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	if _, err := parsePairs("User"); err == nil {
+		t.Error("parsePairs(User) expected error")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "model.go"), []byte(testSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generate(dir, "bean_copy_gen.go", []typePair{{src: "User", dst: "Missing"}}); err == nil {
+		t.Error("generate with missing struct expected error")
+	}
+}
@@ -0,0 +1,176 @@
+package bean
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minlib/go-util/core"
+	"github.com/shopspring/decimal"
+)
+
+// converterKey 转换器的源类型与目标类型
+type converterKey struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+// converterFunc 基于反射的类型转换函数
+type converterFunc func(reflect.Value) (reflect.Value, error)
+
+// converters 已注册的类型转换器
+var converters sync.Map
+
+// RegisterConverter 注册从 From 到 To 的类型转换器，复制时字段类型完全匹配 From 与 To 即使用该转换器，
+// 重复注册会覆盖之前的转换器。指针类型会自动解引用或创建后再匹配，需要特殊处理 nil 时可直接注册指针类型
+func RegisterConverter[From, To any](fn func(From) (To, error)) {
+	from := reflect.TypeOf((*From)(nil)).Elem()
+	to := reflect.TypeOf((*To)(nil)).Elem()
+	converters.Store(converterKey{from: from, to: to}, converterFunc(func(value reflect.Value) (reflect.Value, error) {
+		result, err := fn(value.Interface().(From))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&result).Elem(), nil
+	}))
+}
+
+// lookupConverter 查找已注册的类型转换器
+func lookupConverter(from, to reflect.Type) (converterFunc, bool) {
+	if fn, found := converters.Load(converterKey{from: from, to: to}); found {
+		return fn.(converterFunc), true
+	}
+	return nil, false
+}
+
+// convertNumber 数值类型的无损转换：同类型的拓宽、无符号整数到更宽的有符号整数、
+// 可精确表示的整数到浮点数、float32 到 float64，以及底层类型相同的自定义类型
+func convertNumber(dst, src reflect.Value) bool {
+	srcType, dstType := src.Type(), dst.Type()
+	srcKind, dstKind := srcType.Kind(), dstType.Kind()
+	if srcKind == dstKind && srcType.ConvertibleTo(dstType) && isBasicKind(srcKind) {
+		dst.Set(src.Convert(dstType))
+		return true
+	}
+	srcBits, dstBits := srcType.Bits, dstType.Bits
+	switch {
+	case isIntKind(srcKind) && isIntKind(dstKind) && dstBits() >= srcBits():
+		dst.SetInt(src.Int())
+	case isUintKind(srcKind) && isUintKind(dstKind) && dstBits() >= srcBits():
+		dst.SetUint(src.Uint())
+	case isUintKind(srcKind) && isIntKind(dstKind) && dstBits() > srcBits():
+		dst.SetInt(int64(src.Uint()))
+	case isIntKind(srcKind) && isFloatKind(dstKind) && srcBits() < mantissaBits(dstBits()):
+		dst.SetFloat(float64(src.Int()))
+	case isUintKind(srcKind) && isFloatKind(dstKind) && srcBits() < mantissaBits(dstBits()):
+		dst.SetFloat(float64(src.Uint()))
+	case srcKind == reflect.Float32 && dstKind == reflect.Float64:
+		dst.SetFloat(src.Float())
+	default:
+		return false
+	}
+	return true
+}
+
+// mantissaBits 浮点数可精确表示的整数位数
+func mantissaBits(floatBits int) int {
+	if floatBits == 32 {
+		return 24
+	}
+	return 53
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isBasicKind(kind reflect.Kind) bool {
+	return kind == reflect.Bool || kind == reflect.String || isIntKind(kind) || isUintKind(kind) || isFloatKind(kind)
+}
+
+func init() {
+	// core.Long
+	RegisterConverter(func(v int64) (core.Long, error) { return core.NewLong(v), nil })
+	RegisterConverter(func(v int) (core.Long, error) { return core.NewLong(v), nil })
+	RegisterConverter(func(v int32) (core.Long, error) { return core.NewLong(v), nil })
+	RegisterConverter(func(v uint) (core.Long, error) { return core.NewLong(v), nil })
+	RegisterConverter(func(v uint32) (core.Long, error) { return core.NewLong(v), nil })
+	RegisterConverter(func(v *int64) (core.Long, error) { return core.Long{Int64: copyPtr(v)}, nil })
+	RegisterConverter(func(v core.Long) (int64, error) { return v.Int64Def(), nil })
+	RegisterConverter(func(v core.Long) (int, error) { return int(v.Int64Def()), nil })
+	RegisterConverter(func(v core.Long) (*int64, error) { return copyPtr(v.Int64), nil })
+	RegisterConverter(func(v core.Long) (string, error) { return v.String(), nil })
+	RegisterConverter(func(v string) (core.Long, error) {
+		if v == "" {
+			return core.Long{}, nil
+		}
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return core.Long{}, fmt.Errorf("can not convert %q to core.Long: %w", v, err)
+		}
+		return core.NewLong(i), nil
+	})
+
+	// core.Integer
+	RegisterConverter(func(v int32) (core.Integer, error) { return core.NewInteger(v), nil })
+	RegisterConverter(func(v int16) (core.Integer, error) { return core.NewInteger(v), nil })
+	RegisterConverter(func(v int8) (core.Integer, error) { return core.NewInteger(v), nil })
+	RegisterConverter(func(v *int32) (core.Integer, error) { return core.Integer{Int32: copyPtr(v)}, nil })
+	RegisterConverter(func(v core.Integer) (int32, error) { return v.Int32Def(), nil })
+	RegisterConverter(func(v core.Integer) (int64, error) { return int64(v.Int32Def()), nil })
+	RegisterConverter(func(v core.Integer) (int, error) { return int(v.Int32Def()), nil })
+	RegisterConverter(func(v core.Integer) (*int32, error) { return copyPtr(v.Int32), nil })
+	RegisterConverter(func(v core.Integer) (string, error) { return v.String(), nil })
+	RegisterConverter(func(v core.Integer) (core.Long, error) {
+		if v.Int32 == nil {
+			return core.Long{}, nil
+		}
+		return core.NewLong(*v.Int32), nil
+	})
+
+	// core.DateTime
+	RegisterConverter(func(v time.Time) (core.DateTime, error) { return core.DateTime{Time: v}, nil })
+	RegisterConverter(func(v core.DateTime) (time.Time, error) { return v.Time, nil })
+	RegisterConverter(func(v core.DateTime) (string, error) {
+		if v.IsZero() {
+			return "", nil
+		}
+		return v.String(), nil
+	})
+
+	// decimal.Decimal
+	RegisterConverter(func(v decimal.Decimal) (string, error) { return v.String(), nil })
+	RegisterConverter(func(v decimal.Decimal) (float64, error) { return v.InexactFloat64(), nil })
+	RegisterConverter(func(v string) (decimal.Decimal, error) {
+		if v == "" {
+			return decimal.Zero, nil
+		}
+		d, err := decimal.NewFromString(v)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("can not convert %q to decimal.Decimal: %w", v, err)
+		}
+		return d, nil
+	})
+	RegisterConverter(func(v float64) (decimal.Decimal, error) { return decimal.NewFromFloat(v), nil })
+	RegisterConverter(func(v int64) (decimal.Decimal, error) { return decimal.NewFromInt(v), nil })
+	RegisterConverter(func(v int) (decimal.Decimal, error) { return decimal.NewFromInt(int64(v)), nil })
+}
+
+// copyPtr 复制指针指向的值，避免目标对象与源对象共享
+func copyPtr[E any](p *E) *E {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
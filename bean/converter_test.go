@@ -0,0 +1,130 @@
+package bean
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minlib/go-util/core"
+	"github.com/shopspring/decimal"
+)
+
+type ConvertEntity struct {
+	ID         int64
+	ParentID   *int64
+	Age        int32
+	Score      int16
+	Amount     decimal.Decimal
+	Discount   *decimal.Decimal
+	CreateTime time.Time
+	UpdateTime *time.Time
+	Status     int8
+	Ratio      float32
+}
+
+type ConvertDTO struct {
+	ID         core.Long
+	ParentID   core.Long
+	Age        core.Integer
+	Score      int64
+	Amount     string
+	Discount   string
+	CreateTime core.DateTime
+	UpdateTime *core.DateTime
+	Status     int
+	Ratio      float64
+}
+
+func TestCopyConverter(t *testing.T) {
+	now := time.Now()
+	entity := ConvertEntity{
+		ID:         100,
+		Age:        18,
+		Score:      99,
+		Amount:     decimal.RequireFromString("12.50"),
+		Discount:   core.Decimal(decimal.RequireFromString("0.8")),
+		CreateTime: now,
+		UpdateTime: &now,
+		Status:     3,
+		Ratio:      0.5,
+	}
+	dto, err := CopyTo[ConvertDTO](entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dto.ID.Int64Def() != 100 || dto.ParentID.Int64 != nil || dto.Age.Int32Def() != 18 {
+		t.Errorf("CopyTo() = %+v", dto)
+	}
+	if dto.Score != 99 || dto.Status != 3 || dto.Ratio != 0.5 {
+		t.Errorf("CopyTo() numbers = %+v", dto)
+	}
+	if dto.Amount != "12.5" || dto.Discount != "0.8" {
+		t.Errorf("CopyTo() decimals = %s, %s", dto.Amount, dto.Discount)
+	}
+	if !dto.CreateTime.Time.Equal(now) || dto.UpdateTime == nil || !dto.UpdateTime.Time.Equal(now) {
+		t.Errorf("CopyTo() times = %v, %v", dto.CreateTime, dto.UpdateTime)
+	}
+
+	// 反向复制
+	back, err := CopyTo[ConvertEntity](dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.ID != 100 || back.ParentID != nil || back.Age != 18 || !back.Amount.Equal(entity.Amount) {
+		t.Errorf("CopyTo() = %+v", back)
+	}
+	if back.Discount == nil || !back.Discount.Equal(*entity.Discount) || !back.CreateTime.Equal(now) {
+		t.Errorf("CopyTo() = %+v", back)
+	}
+}
+
+type Money int64
+
+type Price struct {
+	Value string
+}
+
+func TestRegisterConverter(t *testing.T) {
+	RegisterConverter(func(v Money) (Price, error) {
+		if v < 0 {
+			return Price{}, errors.New("negative money")
+		}
+		return Price{Value: decimal.New(int64(v), -2).StringFixed(2)}, nil
+	})
+	type source struct{ Total Money }
+	type target struct{ Total *Price }
+	dto, err := CopyTo[target](source{Total: 1234})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dto.Total == nil || dto.Total.Value != "12.34" {
+		t.Errorf("CopyTo() = %+v", dto.Total)
+	}
+	if _, err := CopyTo[target](source{Total: -1}); err == nil || !strings.Contains(err.Error(), "Total") {
+		t.Errorf("CopyTo() error = %v, want field error", err)
+	}
+}
+
+func TestCopyNumberWidening(t *testing.T) {
+	type source struct {
+		A int8
+		B uint16
+		C int64
+		D uint64
+	}
+	type target struct {
+		A int64
+		B int32
+		C int32
+		D int64
+	}
+	dto, err := CopyTo[target](source{A: -1, B: 65535, C: 1, D: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 有损的转换不会进行
+	if dto.A != -1 || dto.B != 65535 || dto.C != 0 || dto.D != 0 {
+		t.Errorf("CopyTo() = %+v", dto)
+	}
+}
@@ -0,0 +1,267 @@
+package bean
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// visit 已复制的源指针，用于处理循环引用
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// copier 一次复制过程的上下文
+type copier struct {
+	options  *options                // 复制的配置
+	visited  map[visit]reflect.Value // 源指针与目标类型对应的已创建目标指针
+	reporter *reporter               // 诊断信息收集器，为 nil 时不收集
+	path     string                  // 当前字段的路径，仅在收集诊断信息时使用
+}
+
+// newCopier 创建复制上下文
+func newCopier(o *options) *copier {
+	if o == nil {
+		o = newOptions()
+	}
+	return &copier{
+		options: o,
+		visited: make(map[visit]reflect.Value),
+	}
+}
+
+// copyStruct 按映射名称复制结构体的字段，filter 不为空时按其中的白名单与黑名单过滤字段
+// @dst 可设置的目标结构体
+// @src 源结构体
+func (c *copier) copyStruct(dst, src reflect.Value, filter *options) error {
+	plan := loadPlan(src.Type(), dst.Type(), filter)
+	if c.reporter != nil {
+		c.reporter.structFields(c.path, plan)
+	}
+	for _, f := range plan.fields {
+		srcField := src.Field(f.src)
+		if (f.omitEmpty || c.options.omitEmpty) && srcField.IsZero() {
+			continue
+		}
+		dstField := fieldByIndex(dst, f.dst)
+		if !dstField.IsValid() || !dstField.CanSet() {
+			continue
+		}
+		if c.reporter == nil {
+			if _, err := c.copyValue(dstField, srcField); err != nil {
+				return fmt.Errorf("copy field %s: %w", f.field, err)
+			}
+			continue
+		}
+		path := c.path
+		c.path = joinPath(path, f.field)
+		ok, err := c.copyValue(dstField, srcField)
+		if err != nil || !ok && !isNilValue(srcField) {
+			c.reporter.failed(c.path, dstField, srcField, err)
+		}
+		c.path = path
+		if err != nil {
+			return fmt.Errorf("copy field %s: %w", f.field, err)
+		}
+	}
+	return nil
+}
+
+// copyValue 将 src 复制到可设置的 dst，递归处理嵌套的结构体、指针、切片、数组与映射，
+// 类型相同（可赋值）时直接赋值，但其中的指针、切片与映射会深拷贝，修改目标不会影响源对象（接口、函数、通道及未导出字段仍共享）。
+// 类型不同时依次尝试已注册的转换器与数值的无损转换。返回是否进行了赋值
+func (c *copier) copyValue(dst, src reflect.Value) (bool, error) {
+	if !src.IsValid() {
+		return false, nil
+	}
+	if src.Type().AssignableTo(dst.Type()) && !c.merge(dst, src) {
+		switch {
+		case dst.Kind() == reflect.Interface || !hasReference(src.Type()):
+			dst.Set(src)
+			return true, nil
+		case src.Kind() == reflect.Struct:
+			// 整体赋值以保留未导出字段，再深拷贝导出的引用字段
+			dst.Set(src)
+			return true, c.copyReferences(dst, src)
+		}
+		// 指针、切片、数组与映射按下面的规则逐层复制
+	}
+	if fn, found := lookupConverter(src.Type(), dst.Type()); found {
+		result, err := fn(src)
+		if err != nil {
+			return false, err
+		}
+		dst.Set(result)
+		return true, nil
+	}
+	switch {
+	case src.Kind() == reflect.Interface:
+		if src.IsNil() {
+			return false, nil
+		}
+		return c.copyValue(dst, src.Elem())
+	case src.Kind() == reflect.Ptr:
+		if src.IsNil() {
+			if dst.Kind() == reflect.Ptr {
+				dst.Set(reflect.Zero(dst.Type()))
+				return true, nil
+			}
+			return false, nil
+		}
+		if dst.Kind() != reflect.Ptr {
+			return c.copyValue(dst, src.Elem())
+		}
+		// 同一个源指针只复制一次，循环引用时复用已创建的目标指针
+		key := visit{ptr: src.Pointer(), typ: dst.Type()}
+		if target, found := c.visited[key]; found {
+			dst.Set(target)
+			return true, nil
+		}
+		if c.merge(dst, src) {
+			c.visited[key] = dst
+			return true, c.copyStruct(dst.Elem(), src.Elem(), nil)
+		}
+		target := reflect.New(dst.Type().Elem())
+		c.visited[key] = target
+		ok, err := c.copyValue(target.Elem(), src.Elem())
+		if err != nil || !ok {
+			delete(c.visited, key)
+			return false, err
+		}
+		dst.Set(target)
+		return true, nil
+	case dst.Kind() == reflect.Ptr:
+		target := reflect.New(dst.Type().Elem())
+		ok, err := c.copyValue(target.Elem(), src)
+		if err != nil || !ok {
+			return false, err
+		}
+		dst.Set(target)
+		return true, nil
+	}
+	switch src.Kind() {
+	case reflect.Struct:
+		if dst.Kind() != reflect.Struct {
+			return false, nil
+		}
+		return true, c.copyStruct(dst, src, nil)
+	case reflect.Slice, reflect.Array:
+		return c.copySlice(dst, src)
+	case reflect.Map:
+		return c.copyMap(dst, src)
+	default:
+		return convertNumber(dst, src), nil
+	}
+}
+
+// copyReferences 深拷贝同类型结构体中导出的引用字段，使其不与源对象共享
+func (c *copier) copyReferences(dst, src reflect.Value) error {
+	t := src.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); !f.IsExported() || !hasReference(f.Type) {
+			continue
+		}
+		if _, err := c.copyValue(dst.Field(i), src.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// references 类型是否包含需要深拷贝的引用
+var references sync.Map
+
+// hasReference 判断类型的值是否包含需要深拷贝的指针、切片或映射，只检查结构体的导出字段
+func hasReference(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return hasReference(t.Elem())
+	case reflect.Struct:
+		if v, found := references.Load(t); found {
+			return v.(bool)
+		}
+		result := false
+		for i := 0; i < t.NumField() && !result; i++ {
+			f := t.Field(i)
+			result = f.IsExported() && hasReference(f.Type)
+		}
+		references.Store(t, result)
+		return result
+	default:
+		return false
+	}
+}
+
+// merge 是否需要将源结构体合并到已有的目标结构体，跳过零值时已有的嵌套结构体只更新非零值字段
+func (c *copier) merge(dst, src reflect.Value) bool {
+	return c.options.omitEmpty && src.Kind() == reflect.Ptr && dst.Kind() == reflect.Ptr && !src.IsNil() && !dst.IsNil() &&
+		src.Type().Elem().Kind() == reflect.Struct && dst.Type().Elem().Kind() == reflect.Struct
+}
+
+// copySlice 逐项复制切片或数组
+func (c *copier) copySlice(dst, src reflect.Value) (bool, error) {
+	switch dst.Kind() {
+	case reflect.Slice:
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return true, nil
+		}
+		target := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		if elem := dst.Type().Elem(); src.Type().Elem() == elem && !hasReference(elem) {
+			reflect.Copy(target, src)
+			dst.Set(target)
+			return true, nil
+		}
+		for i := 0; i < src.Len(); i++ {
+			if _, err := c.copyValue(target.Index(i), src.Index(i)); err != nil {
+				return false, err
+			}
+		}
+		dst.Set(target)
+		return true, nil
+	case reflect.Array:
+		target := reflect.New(dst.Type()).Elem()
+		for i := 0; i < src.Len() && i < target.Len(); i++ {
+			if _, err := c.copyValue(target.Index(i), src.Index(i)); err != nil {
+				return false, err
+			}
+		}
+		dst.Set(target)
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// copyMap 逐项复制映射的键与值
+func (c *copier) copyMap(dst, src reflect.Value) (bool, error) {
+	if dst.Kind() != reflect.Map {
+		return false, nil
+	}
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return true, nil
+	}
+	keyType, elemType := dst.Type().Key(), dst.Type().Elem()
+	target := reflect.MakeMapWithSize(dst.Type(), src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		key := reflect.New(keyType).Elem()
+		if ok, err := c.copyValue(key, iter.Key()); err != nil || !ok {
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+		elem := reflect.New(elemType).Elem()
+		if _, err := c.copyValue(elem, iter.Value()); err != nil {
+			return false, err
+		}
+		target.SetMapIndex(key, elem)
+	}
+	dst.Set(target)
+	return true, nil
+}
@@ -0,0 +1,224 @@
+package bean
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minlib/go-util/core"
+)
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type AddressDTO struct {
+	City   string
+	Street string
+}
+
+type Item struct {
+	Name  string
+	Count int
+}
+
+type ItemDTO struct {
+	Name  string
+	Count int
+}
+
+type Order struct {
+	ID         int64
+	Address    Address
+	Billing    *Address
+	Items      []Item
+	ItemPtrs   []*Item
+	Extras     map[string]Item
+	Tags       [2]string
+	CreateTime time.Time
+}
+
+type OrderDTO struct {
+	ID         int64
+	Address    AddressDTO
+	Billing    AddressDTO
+	Items      []ItemDTO
+	ItemPtrs   []ItemDTO
+	Extras     map[string]*ItemDTO
+	Tags       []string
+	CreateTime time.Time
+}
+
+type Node struct {
+	Name     string
+	Parent   *Node
+	Children []*Node
+}
+
+type NodeDTO struct {
+	Name     string
+	Parent   *NodeDTO
+	Children []*NodeDTO
+}
+
+func TestCopyNested(t *testing.T) {
+	order := Order{
+		ID:         1,
+		Address:    Address{City: "深圳", Street: "科技园"},
+		Billing:    &Address{City: "广州"},
+		Items:      []Item{{Name: "苹果", Count: 2}, {Name: "香蕉", Count: 3}},
+		ItemPtrs:   []*Item{{Name: "橙子", Count: 1}, nil},
+		Extras:     map[string]Item{"gift": {Name: "贺卡", Count: 1}},
+		Tags:       [2]string{"a", "b"},
+		CreateTime: time.Now(),
+	}
+	dto, err := CopyTo[OrderDTO](&order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dto.ID != 1 || dto.Address.City != "深圳" || dto.Billing.City != "广州" {
+		t.Errorf("CopyTo() = %+v", dto)
+	}
+	if len(dto.Items) != 2 || dto.Items[1].Name != "香蕉" || dto.Items[1].Count != 3 {
+		t.Errorf("CopyTo() Items = %+v", dto.Items)
+	}
+	if len(dto.ItemPtrs) != 2 || dto.ItemPtrs[0].Name != "橙子" || dto.ItemPtrs[1].Name != "" {
+		t.Errorf("CopyTo() ItemPtrs = %+v", dto.ItemPtrs)
+	}
+	if gift := dto.Extras["gift"]; gift == nil || gift.Name != "贺卡" {
+		t.Errorf("CopyTo() Extras = %+v", dto.Extras)
+	}
+	if len(dto.Tags) != 2 || dto.Tags[1] != "b" {
+		t.Errorf("CopyTo() Tags = %+v", dto.Tags)
+	}
+	if !dto.CreateTime.Equal(order.CreateTime) {
+		t.Errorf("CopyTo() CreateTime = %v", dto.CreateTime)
+	}
+	// 目标对象不与源对象共享嵌套的切片
+	dto.Items[0].Name = "修改"
+	if order.Items[0].Name != "苹果" {
+		t.Errorf("CopyTo() should not share nested slices")
+	}
+}
+
+func TestCopyNestedSlice(t *testing.T) {
+	orders := []Order{{ID: 1, Items: []Item{{Name: "苹果"}}}, {ID: 2}}
+	var dtos []*OrderDTO
+	if err := Copy(orders, &dtos); err != nil {
+		t.Fatal(err)
+	}
+	if len(dtos) != 2 || dtos[0].Items[0].Name != "苹果" || dtos[1].Items != nil {
+		t.Errorf("Copy() = %+v", dtos)
+	}
+}
+
+func TestCopyCycle(t *testing.T) {
+	root := &Node{Name: "root"}
+	child := &Node{Name: "child", Parent: root}
+	root.Children = []*Node{child}
+	root.Parent = root
+
+	dto, err := CopyTo[*NodeDTO](root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dto.Parent != dto {
+		t.Errorf("CopyTo() should keep self reference")
+	}
+	if len(dto.Children) != 1 || dto.Children[0].Name != "child" || dto.Children[0].Parent != dto {
+		t.Errorf("CopyTo() should keep parent reference")
+	}
+}
+
+type Account struct {
+	Id      core.Long
+	Balance *int64
+	Roles   []string
+	Limits  map[string]int
+	Orders  []Order
+}
+
+func TestCopySameTypeDeep(t *testing.T) {
+	balance := int64(100)
+	src := Account{
+		Id:      core.NewLong(1),
+		Balance: &balance,
+		Roles:   []string{"admin"},
+		Limits:  map[string]int{"daily": 10},
+		Orders:  []Order{{ID: 1, Billing: &Address{City: "广州"}, ItemPtrs: []*Item{{Name: "橙子"}}, Extras: map[string]Item{"gift": {Name: "贺卡"}}}},
+	}
+	var dst Account
+	if err := Copy(&src, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, src) {
+		t.Fatalf("Copy() = %+v, want %+v", dst, src)
+	}
+	// 修改目标对象不影响源对象
+	*dst.Id.Int64 = 2
+	*dst.Balance = 200
+	dst.Roles[0] = "guest"
+	dst.Limits["daily"] = 20
+	dst.Orders[0].Billing.City = "深圳"
+	dst.Orders[0].ItemPtrs[0].Name = "苹果"
+	dst.Orders[0].Extras["gift"] = Item{Name: "鲜花"}
+	if *src.Id.Int64 != 1 || balance != 100 || src.Roles[0] != "admin" || src.Limits["daily"] != 10 {
+		t.Errorf("Copy() should not share pointers, slices and maps, source = %+v", src)
+	}
+	if order := src.Orders[0]; order.Billing.City != "广州" || order.ItemPtrs[0].Name != "橙子" || order.Extras["gift"].Name != "贺卡" {
+		t.Errorf("Copy() should not share nested references, source = %+v", order)
+	}
+}
+
+func TestCopySameTypeCycle(t *testing.T) {
+	root := &Node{Name: "root"}
+	root.Children = []*Node{{Name: "child", Parent: root}}
+	dst, err := CopyTo[*Node](root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst == root || dst.Children[0] == root.Children[0] || dst.Children[0].Parent != dst {
+		t.Errorf("CopyTo() should copy the graph and keep the parent reference")
+	}
+}
+
+func newBenchmarkOrders(n int) []Order {
+	orders := make([]Order, n)
+	for i := range orders {
+		orders[i] = Order{
+			ID:      int64(i),
+			Address: Address{City: "Shenzhen", Street: "Nanshan"},
+			Items:   []Item{{Name: "apple", Count: 1}, {Name: "pear", Count: 2}},
+			Tags:    [2]string{"a", "b"},
+		}
+	}
+	return orders
+}
+
+func BenchmarkCopySlice(b *testing.B) {
+	orders := newBenchmarkOrders(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var target []OrderDTO
+		if err := Copy(orders, &target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCopySliceWithoutPlanCache(b *testing.B) {
+	orders := newBenchmarkOrders(1000)
+	b.ResetTimer()
+	targetType := reflect.TypeOf(OrderDTO{})
+	for i := 0; i < b.N; i++ {
+		c := newCopier(nil)
+		for j := range orders {
+			// 每项都重新构建复制计划，与缓存前的行为一致
+			plans.Clear()
+			if err := c.copyItem(reflect.New(targetType).Elem(), reflect.ValueOf(orders[j])); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package bean
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeType 字段变更的类型
+type ChangeType int
+
+const (
+	// ChangeModified 字段的值被修改
+	ChangeModified ChangeType = iota
+	// ChangeAdded 切片中新增的元素或映射中新增的键
+	ChangeAdded
+	// ChangeRemoved 切片中删除的元素或映射中删除的键
+	ChangeRemoved
+)
+
+// String 返回变更类型的名称
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	default:
+		return "modified"
+	}
+}
+
+// Change 字段的变更，Path 为字段路径，如 Name、Address.City、Items[0].Count、Extras["vip"]
+type Change struct {
+	Path string      // 字段路径
+	Type ChangeType  // 变更类型
+	Old  interface{} // 旧值，新增时为 nil
+	New  interface{} // 新值，删除时为 nil
+}
+
+// String 返回变更的描述，如 Name: admin -> root
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// Diff 比较同一类型的两个结构体，返回所有变更的字段。嵌套的结构体、指针、切片、数组与映射会递归比较，
+// 切片按下标比较，长度不同时多出的元素为新增或删除；time.Time、core.Long、core.DateTime、decimal.Decimal
+// 等自定义了序列化方式的类型按值比较，如 decimal 的 1.0 与 1.00 相等。标记为 `bean:"-"` 的字段不比较
+// @a 旧对象
+// @b 新对象
+func Diff(a, b interface{}) ([]Change, error) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() {
+		return nil, errors.New("diff value invalid")
+	}
+	for _, v := range []*reflect.Value{&av, &bv} {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, errors.New("diff value can't nil")
+			}
+			*v = v.Elem()
+		}
+	}
+	if av.Type() != bv.Type() {
+		return nil, fmt.Errorf("can not diff %s with %s", av.Type(), bv.Type())
+	}
+	if av.Kind() != reflect.Struct {
+		return nil, errors.New("diff value must be a struct")
+	}
+	d := &differ{visited: make(map[[2]uintptr]struct{})}
+	d.diff("", av, bv)
+	return d.changes, nil
+}
+
+// differ 一次比较的上下文
+type differ struct {
+	changes []Change                // 已发现的变更
+	visited map[[2]uintptr]struct{} // 已比较的指针对，用于处理循环引用
+}
+
+// add 记录变更
+func (d *differ) add(path string, changeType ChangeType, old, new reflect.Value) {
+	d.changes = append(d.changes, Change{Path: path, Type: changeType, Old: interfaceOf(old), New: interfaceOf(new)})
+}
+
+// diff 递归比较两个同类型的值
+func (d *differ) diff(path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, ChangeModified, a, b)
+			}
+			return
+		}
+		key := [2]uintptr{a.Pointer(), b.Pointer()}
+		if _, found := d.visited[key]; found || key[0] == key[1] {
+			return
+		}
+		d.visited[key] = struct{}{}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() || a.Elem().Type() != b.Elem().Type() {
+			if !(a.IsNil() && b.IsNil()) {
+				d.add(path, ChangeModified, a, b)
+			}
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		if !needsMapping(a.Type()) {
+			if !valueEqual(a, b) {
+				d.add(path, ChangeModified, a, b)
+			}
+			return
+		}
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || parseFieldTag(field).ignore {
+				continue
+			}
+			// 嵌入结构体（非指针）的字段按展开后的名称表示
+			fieldPath := joinPath(path, field.Name)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && needsMapping(field.Type) {
+				fieldPath = path
+			}
+			d.diff(fieldPath, a.Field(i), b.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < a.Len() || i < b.Len(); i++ {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= a.Len():
+				d.add(itemPath, ChangeAdded, reflect.Value{}, b.Index(i))
+			case i >= b.Len():
+				d.add(itemPath, ChangeRemoved, a.Index(i), reflect.Value{})
+			default:
+				d.diff(itemPath, a.Index(i), b.Index(i))
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(a, b) {
+			itemPath := path + "[" + formatKey(key) + "]"
+			av, bv := a.MapIndex(key), b.MapIndex(key)
+			switch {
+			case !av.IsValid():
+				d.add(itemPath, ChangeAdded, reflect.Value{}, bv)
+			case !bv.IsValid():
+				d.add(itemPath, ChangeRemoved, av, reflect.Value{})
+			default:
+				d.diff(itemPath, av, bv)
+			}
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		// 不可比较的类型忽略
+	default:
+		if !valueEqual(a, b) {
+			d.add(path, ChangeModified, a, b)
+		}
+	}
+}
+
+// interfaceOf 返回值的 interface{}，无效的值或 nil 指针返回 nil
+func interfaceOf(v reflect.Value) interface{} {
+	if !v.IsValid() || isNilValue(v) {
+		return nil
+	}
+	return v.Interface()
+}
+
+// sortedKeys 返回两个映射所有键的并集，按格式化后的字符串排序保证结果稳定
+func sortedKeys(a, b reflect.Value) []reflect.Value {
+	seen := make(map[interface{}]struct{}, a.Len()+b.Len())
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{a, b} {
+		for _, key := range m.MapKeys() {
+			if _, found := seen[key.Interface()]; !found {
+				seen[key.Interface()] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	sortValues(keys)
+	return keys
+}
+
+// sortValues 按格式化后的字符串排序
+func sortValues(values []reflect.Value) {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = fmt.Sprint(v.Interface())
+	}
+	sort.Sort(valueSorter{values: values, formatted: formatted})
+}
+
+// valueSorter 按格式化后的字符串排序 reflect.Value
+type valueSorter struct {
+	values    []reflect.Value
+	formatted []string
+}
+
+func (s valueSorter) Len() int           { return len(s.values) }
+func (s valueSorter) Less(i, j int) bool { return s.formatted[i] < s.formatted[j] }
+func (s valueSorter) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.formatted[i], s.formatted[j] = s.formatted[j], s.formatted[i]
+}
+
+// formatKey 格式化路径中映射的键，字符串使用带引号的形式
+func formatKey(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return strconv.Quote(key.String())
+	}
+	return fmt.Sprint(key.Interface())
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// valueEqual 按值比较两个同类型的值：优先使用类型自身的 Equal 方法（如 time.Time、decimal.Decimal），
+// 其次比较 driver.Valuer 返回的值（如 core.Long、core.DateTime），否则深度比较
+func valueEqual(a, b reflect.Value) bool {
+	if method := a.MethodByName("Equal"); method.IsValid() {
+		mt := method.Type()
+		if mt.NumIn() == 1 && mt.In(0) == a.Type() && mt.NumOut() == 1 && mt.Out(0).Kind() == reflect.Bool {
+			return method.Call([]reflect.Value{b})[0].Bool()
+		}
+	}
+	if a.Type().Implements(valuerType) {
+		av, aErr := a.Interface().(driver.Valuer).Value()
+		bv, bErr := b.Interface().(driver.Valuer).Value()
+		if aErr == nil && bErr == nil {
+			at, aok := av.(time.Time)
+			bt, bok := bv.(time.Time)
+			if aok && bok {
+				return at.Equal(bt)
+			}
+			return reflect.DeepEqual(av, bv)
+		}
+	}
+	if a.Type() == timeType {
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// Apply 将 Diff 返回的变更应用到目标对象，目标对象中路径上为 nil 的指针、映射会自动创建。
+// 新值按 FromMap 的规则转换后写入，切片中删除的元素从该下标起截断（Diff 只会删除末尾的元素）
+// @target 目标结构体的指针
+// @changes 变更列表
+func Apply(target interface{}, changes []Change) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr {
+		return errors.New("target value can't a pointer type")
+	}
+	if targetValue.IsNil() {
+		return errors.New("target value can't be nil")
+	}
+	targetValue = NewPointer(targetValue)
+	if targetValue.Kind() != reflect.Struct {
+		return errors.New("target value must be a struct")
+	}
+	m := &mapper{options: newOptions()}
+	for _, change := range changes {
+		segments, err := parsePath(change.Path)
+		if err != nil {
+			return err
+		}
+		if err := m.apply(targetValue, segments, change); err != nil {
+			return fmt.Errorf("apply %s: %w", change.Path, err)
+		}
+	}
+	return nil
+}
+
+// pathSegment 字段路径的片段
+type pathSegment struct {
+	field   string // 字段名称
+	index   string // 下标或映射的键，isIndex 为 true 时有效
+	quoted  bool   // 键是否为带引号的字符串
+	isIndex bool   // 是否为下标或映射的键
+}
+
+// parsePath 解析字段路径
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	for rest := path; rest != ""; {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+		case '[':
+			rest = rest[1:]
+			if strings.HasPrefix(rest, `"`) {
+				quoted, err := strconv.QuotedPrefix(rest)
+				if err != nil {
+					return nil, fmt.Errorf("invalid path %q", path)
+				}
+				key, _ := strconv.Unquote(quoted)
+				rest = rest[len(quoted):]
+				if !strings.HasPrefix(rest, "]") {
+					return nil, fmt.Errorf("invalid path %q", path)
+				}
+				segments = append(segments, pathSegment{index: key, quoted: true, isIndex: true})
+				rest = rest[1:]
+				continue
+			}
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q", path)
+			}
+			segments = append(segments, pathSegment{index: rest[:end], isIndex: true})
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			segments = append(segments, pathSegment{field: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("path can't be empty")
+	}
+	return segments, nil
+}
+
+// apply 沿路径找到字段并应用变更，v 必须可设置
+func (m *mapper) apply(v reflect.Value, segments []pathSegment, change Change) error {
+	if len(segments) == 0 {
+		return m.fromValue(v, change.New)
+	}
+	v = NewPointer(v)
+	segment, rest := segments[0], segments[1:]
+	if !segment.isIndex {
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("%s is not a struct", v.Type())
+		}
+		field, found := v.Type().FieldByName(segment.field)
+		if !found || !field.IsExported() {
+			return fmt.Errorf("field %s not found in %s", segment.field, v.Type())
+		}
+		fv := fieldByIndex(v, field.Index)
+		if !fv.IsValid() || !fv.CanSet() {
+			return fmt.Errorf("field %s can't be set", segment.field)
+		}
+		return m.apply(fv, rest, change)
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(segment.index)
+		if err != nil || i < 0 {
+			return fmt.Errorf("invalid index %q", segment.index)
+		}
+		if len(rest) == 0 && change.Type == ChangeRemoved {
+			if v.Kind() == reflect.Slice && i < v.Len() {
+				v.SetLen(i)
+			}
+			return nil
+		}
+		if v.Kind() == reflect.Slice && i == v.Len() {
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+		}
+		if i >= v.Len() {
+			return fmt.Errorf("index %d out of range %d", i, v.Len())
+		}
+		return m.apply(v.Index(i), rest, change)
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		if err := m.fromValue(key, segment.index); err != nil {
+			return err
+		}
+		if len(rest) == 0 && change.Type == ChangeRemoved {
+			if !v.IsNil() {
+				v.SetMapIndex(key, reflect.Value{})
+			}
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		// 映射的元素不可寻址，复制后修改再写回
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if exist := v.MapIndex(key); exist.IsValid() {
+			elem.Set(exist)
+		}
+		if err := m.apply(elem, rest, change); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	default:
+		return fmt.Errorf("%s can't be indexed", v.Type())
+	}
+}
@@ -0,0 +1,135 @@
+package bean
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minlib/go-util/core"
+	"github.com/shopspring/decimal"
+)
+
+type DiffBase struct {
+	Id         core.Long
+	UpdateTime core.DateTime
+}
+
+type DiffItem struct {
+	Name  string
+	Count int
+}
+
+type DiffOrder struct {
+	DiffBase
+	Status   int
+	Amount   decimal.Decimal
+	Remark   *string
+	Address  Address
+	Items    []DiffItem
+	Extras   map[string]DiffItem
+	Tags     map[int]string
+	Password string `bean:"-"`
+}
+
+func newDiffOrder() DiffOrder {
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.Local)
+	return DiffOrder{
+		DiffBase: DiffBase{Id: core.NewLong(int64(1)), UpdateTime: core.DateTime{Time: now}},
+		Status:   1,
+		Amount:   decimal.RequireFromString("10.0"),
+		Address:  Address{City: "Shenzhen", Street: "Nanshan"},
+		Items:    []DiffItem{{Name: "apple", Count: 1}, {Name: "pear", Count: 2}},
+		Extras:   map[string]DiffItem{"gift": {Name: "card", Count: 1}},
+		Tags:     map[int]string{1: "a"},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := newDiffOrder()
+	b := newDiffOrder()
+	// 按值比较：同一时刻的不同时区、不同精度的 decimal 与新分配的 core.Long 都视为相等
+	b.UpdateTime = core.DateTime{Time: a.UpdateTime.UTC()}
+	b.Amount = decimal.RequireFromString("10.00")
+	b.Id = core.NewLong(int64(1))
+	b.Password = "changed"
+	changes, err := Diff(a, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Diff(equal) = %v, want no changes", changes)
+	}
+
+	remark := "urgent"
+	b.Id = core.NewLong(int64(2))
+	b.Status = 2
+	b.Remark = &remark
+	b.Address.City = "Guangzhou"
+	b.Items = []DiffItem{{Name: "apple", Count: 3}}
+	b.Extras = map[string]DiffItem{"gift": {Name: "card", Count: 1}, "coupon": {Name: "c1"}}
+	b.Tags = map[int]string{}
+	changes, err = Diff(&a, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{
+		{Path: "Id", Type: ChangeModified, Old: a.Id, New: b.Id},
+		{Path: "Status", Type: ChangeModified, Old: 1, New: 2},
+		{Path: "Remark", Type: ChangeModified, Old: nil, New: &remark},
+		{Path: "Address.City", Type: ChangeModified, Old: "Shenzhen", New: "Guangzhou"},
+		{Path: "Items[0].Count", Type: ChangeModified, Old: 1, New: 3},
+		{Path: "Items[1]", Type: ChangeRemoved, Old: DiffItem{Name: "pear", Count: 2}, New: nil},
+		{Path: `Extras["coupon"]`, Type: ChangeAdded, Old: nil, New: DiffItem{Name: "c1"}},
+		{Path: "Tags[1]", Type: ChangeRemoved, Old: "a", New: nil},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() =\n%v\nwant\n%v", changes, want)
+	}
+
+	if _, err := Diff(a, Address{}); err == nil {
+		t.Error("Diff(different types) expected error")
+	}
+}
+
+func TestApply(t *testing.T) {
+	a := newDiffOrder()
+	b := newDiffOrder()
+	remark := "urgent"
+	b.Id = core.NewLong(int64(2))
+	b.Remark = &remark
+	b.Address.City = "Guangzhou"
+	b.Items = []DiffItem{{Name: "apple", Count: 3}, {Name: "pear", Count: 2}, {Name: "plum"}}
+	b.Extras = map[string]DiffItem{"gift": {Name: "card", Count: 2}}
+	b.Tags = map[int]string{2: "b"}
+	changes, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := newDiffOrder()
+	if err := Apply(&target, changes); err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Diff(target, b); err != nil || len(changes) != 0 {
+		t.Errorf("Diff(applied, b) = %v, %v, want no changes", changes, err)
+	}
+
+	// 删除切片末尾的元素
+	changes, err = Diff(b, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(&target, changes); err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Diff(target, a); err != nil || len(changes) != 0 {
+		t.Errorf("Diff(reverted, a) = %v, %v, want no changes", changes, err)
+	}
+
+	if err := Apply(&target, []Change{{Path: "Missing", New: 1}}); err == nil {
+		t.Error("Apply(missing field) expected error")
+	}
+	if err := Apply(&target, []Change{{Path: "Items[9].Name", New: "x"}}); err == nil {
+		t.Error("Apply(out of range) expected error")
+	}
+}
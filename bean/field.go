@@ -0,0 +1,191 @@
+package bean
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// tagName 字段映射使用的标签名称
+const tagName = "bean"
+
+// fieldTag 解析后的 bean 标签，如 `bean:"name=UserName,omitempty"`、`bean:"ignore"` 或 `bean:"-"`
+type fieldTag struct {
+	name      string // 映射名称，默认为字段名称
+	ignore    bool   // 不参与复制
+	omitEmpty bool   // 零值时不复制
+}
+
+// parseFieldTag 解析字段的 bean 标签
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag := fieldTag{name: field.Name}
+	value, found := field.Tag.Lookup(tagName)
+	if !found {
+		return tag
+	}
+	if value == "-" {
+		tag.ignore = true
+		return tag
+	}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "ignore":
+			tag.ignore = true
+		case part == "omitempty":
+			tag.omitEmpty = true
+		case strings.HasPrefix(part, "name="):
+			if name := strings.TrimSpace(strings.TrimPrefix(part, "name=")); name != "" {
+				tag.name = name
+			}
+		}
+	}
+	return tag
+}
+
+// fieldPlan 源字段到目标字段的复制计划
+type fieldPlan struct {
+	field     string // 源字段名称
+	name      string // 映射名称
+	src       int    // 源字段索引
+	dst       []int  // 目标字段索引路径，可包含嵌入结构体
+	omitEmpty bool   // 源字段为零值时不复制
+}
+
+// structPlan 源结构体到目标结构体的复制计划
+type structPlan struct {
+	fields    []fieldPlan // 匹配的字段
+	unmatched []fieldRef  // 源结构体中没有对应目标字段的字段
+	unset     []fieldRef  // 目标结构体中没有对应源字段的字段
+}
+
+// fieldRef 字段名称与映射名称
+type fieldRef struct {
+	field string // 字段名称
+	name  string // 映射名称
+}
+
+// planKey 复制计划的缓存键
+type planKey struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+// plans 已编译的复制计划
+var plans sync.Map
+
+// loadPlan 获取源结构体到目标结构体的复制计划，按类型缓存未过滤的计划，避免每次复制都遍历字段。
+// filter 中有白名单或黑名单时每次调用按其过滤，过滤后的计划不缓存，避免缓存随字段组合无限增长
+func loadPlan(srcType, dstType reflect.Type, filter *options) *structPlan {
+	key := planKey{src: srcType, dst: dstType}
+	var plan *structPlan
+	if cached, found := plans.Load(key); found {
+		plan = cached.(*structPlan)
+	} else {
+		actual, _ := plans.LoadOrStore(key, buildPlan(srcType, dstType))
+		plan = actual.(*structPlan)
+	}
+	if filter == nil || len(filter.fields) == 0 && len(filter.ignores) == 0 {
+		return plan
+	}
+	filtered := &structPlan{fields: make([]fieldPlan, 0, len(plan.fields))}
+	for _, p := range plan.fields {
+		if filter.allow(p.field, p.name) {
+			filtered.fields = append(filtered.fields, p)
+		}
+	}
+	for _, ref := range plan.unmatched {
+		if filter.allow(ref.field, ref.name) {
+			filtered.unmatched = append(filtered.unmatched, ref)
+		}
+	}
+	for _, ref := range plan.unset {
+		if filter.allow(ref.field, ref.name) {
+			filtered.unset = append(filtered.unset, ref)
+		}
+	}
+	return filtered
+}
+
+// buildPlan 按映射名称匹配源结构体与目标结构体的字段。映射名称默认为字段名称，
+// 可通过 bean 标签的 name 修改，任意一侧标记为 ignore 的字段不参与复制
+func buildPlan(srcType, dstType reflect.Type) *structPlan {
+	visibleFields := reflect.VisibleFields(dstType)
+	dstFields := make(map[string]reflect.StructField)
+	for _, field := range visibleFields {
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseFieldTag(field)
+		if tag.ignore {
+			continue
+		}
+		// 同名时外层字段优先，与 FieldByName 一致
+		if exist, found := dstFields[tag.name]; found && len(exist.Index) <= len(field.Index) {
+			continue
+		}
+		dstFields[tag.name] = field
+	}
+	plan := &structPlan{fields: make([]fieldPlan, 0, srcType.NumField())}
+	for i := 0; i < srcType.NumField(); i++ {
+		field := srcType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseFieldTag(field)
+		if tag.ignore {
+			continue
+		}
+		dstField, found := dstFields[tag.name]
+		if !found {
+			plan.unmatched = append(plan.unmatched, fieldRef{field: field.Name, name: tag.name})
+			continue
+		}
+		plan.fields = append(plan.fields, fieldPlan{
+			field:     field.Name,
+			name:      tag.name,
+			src:       i,
+			dst:       dstField.Index,
+			omitEmpty: tag.omitEmpty || parseFieldTag(dstField).omitEmpty,
+		})
+	}
+	for _, field := range visibleFields {
+		name := parseFieldTag(field).name
+		// 嵌入结构体本身不作为目标字段，由其展开的字段表示
+		if field.Anonymous || dstFields[name].Index == nil || !slices.Equal(dstFields[name].Index, field.Index) {
+			continue
+		}
+		if !plan.covers(field.Index) {
+			plan.unset = append(plan.unset, fieldRef{field: field.Name, name: name})
+		}
+	}
+	return plan
+}
+
+// covers 判断目标字段是否会被复制，字段本身或其所在的嵌入结构体被匹配时返回 true
+func (p *structPlan) covers(index []int) bool {
+	for _, f := range p.fields {
+		if len(f.dst) <= len(index) && slices.Equal(f.dst, index[:len(f.dst)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex 按索引路径获取字段，路径中为 nil 的嵌入指针会自动创建，无法创建时返回无效的值
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
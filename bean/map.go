@@ -0,0 +1,463 @@
+package bean
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/minlib/go-util/mapx"
+)
+
+// mapField 结构体字段与 map 键的对应关系
+type mapField struct {
+	field     string // 字段名称
+	key       string // map 的键
+	index     []int  // 字段索引路径，展开的嵌入结构体字段包含多级
+	omitEmpty bool   // 零值时不输出
+}
+
+// mapFieldsKey 字段对应关系的缓存键
+type mapFieldsKey struct {
+	typ          reflect.Type
+	tag          string
+	keepEmbedded bool
+}
+
+// mapFieldsCache 已解析的字段对应关系
+var mapFieldsCache sync.Map
+
+// loadMapFields 获取结构体字段与 map 键的对应关系，按类型、标签名称与是否保留嵌入结构体缓存
+func loadMapFields(t reflect.Type, tag string, keepEmbedded bool) []mapField {
+	key := mapFieldsKey{typ: t, tag: tag, keepEmbedded: keepEmbedded}
+	if fields, found := mapFieldsCache.Load(key); found {
+		return fields.([]mapField)
+	}
+	fields := buildMapFields(t, tag, keepEmbedded)
+	actual, _ := mapFieldsCache.LoadOrStore(key, fields)
+	return actual.([]mapField)
+}
+
+// buildMapFields 解析结构体字段与 map 键的对应关系，规则与 encoding/json 一致：
+// 标签为 "-" 的字段忽略，未指定名称时使用字段名称，同名时外层字段优先
+func buildMapFields(t reflect.Type, tag string, keepEmbedded bool) []mapField {
+	var fields []mapField
+	depths := make(map[string]int)
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, opts, tagged := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" && !tagged {
+				continue
+			}
+			fieldIndex := append(append([]int(nil), index...), i)
+			if field.Anonymous && name == "" && !keepEmbedded {
+				embedded := field.Type
+				if embedded.Kind() == reflect.Ptr {
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					walk(embedded, fieldIndex)
+					continue
+				}
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if depth, found := depths[name]; found && depth <= len(fieldIndex) {
+				continue
+			}
+			depths[name] = len(fieldIndex)
+			fields = append(fields, mapField{
+				field:     field.Name,
+				key:       name,
+				index:     fieldIndex,
+				omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			})
+		}
+	}
+	walk(t, nil)
+	// 移除被外层同名字段覆盖的字段
+	result := fields[:0]
+	for _, f := range fields {
+		if depths[f.key] == len(f.index) {
+			result = append(result, f)
+			depths[f.key] = -1
+		}
+	}
+	return result
+}
+
+// mapper 一次 ToMap 或 FromMap 的上下文
+type mapper struct {
+	options  *options             // 配置
+	visiting map[uintptr]struct{} // 当前路径上正在转换的源指针，用于发现循环引用
+}
+
+// ToMap 将结构体转为 map[string]interface{}，键默认取 json 标签，可通过 WithTag 修改。
+// 嵌套的结构体递归转为 map，结构体切片转为 []interface{}，实现了 json.Marshaler、
+// encoding.TextMarshaler 或 driver.Valuer 的类型（如 time.Time、core.Long、decimal.Decimal）保持原值。
+// 支持 WithFields、WithIgnoreFields、WithOmitEmpty、WithTag 与 WithKeepEmbedded
+// @source 源结构体或其指针
+// @opts 转换的配置
+func ToMap(source interface{}, opts ...Option) (map[string]interface{}, error) {
+	sourceValue := reflect.ValueOf(source)
+	if !sourceValue.IsValid() {
+		return nil, errors.New("source value invalid")
+	}
+	for sourceValue.Kind() == reflect.Ptr {
+		if sourceValue.IsNil() {
+			return nil, errors.New("source value can't nil")
+		}
+		sourceValue = sourceValue.Elem()
+	}
+	if sourceValue.Kind() != reflect.Struct {
+		return nil, errors.New("source value must be a struct")
+	}
+	m := &mapper{options: newOptions(opts...), visiting: make(map[uintptr]struct{})}
+	return m.toMap(sourceValue, m.options)
+}
+
+// toMap 将结构体转为 map，filter 不为空时按其中的白名单与黑名单过滤字段
+func (m *mapper) toMap(v reflect.Value, filter *options) (map[string]interface{}, error) {
+	fields := loadMapFields(v.Type(), m.options.tag, m.options.keepEmbedded)
+	result := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		if !filter.allow(f.field, f.key) {
+			continue
+		}
+		fv, ok := readField(v, f.index)
+		if !ok || (f.omitEmpty || m.options.omitEmpty) && fv.IsZero() {
+			continue
+		}
+		value, err := m.toValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.field, err)
+		}
+		result[f.key] = value
+	}
+	return result, nil
+}
+
+// toValue 将字段值转为 map 中的值
+func (m *mapper) toValue(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if !needsMapping(v.Type()) {
+			return m.toValue(v.Elem())
+		}
+		ptr := v.Pointer()
+		if _, found := m.visiting[ptr]; found {
+			return nil, fmt.Errorf("cycle detected at %s", v.Type())
+		}
+		m.visiting[ptr] = struct{}{}
+		defer delete(m.visiting, ptr)
+		return m.toValue(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return m.toValue(v.Elem())
+	case reflect.Struct:
+		if !needsMapping(v.Type()) {
+			return v.Interface(), nil
+		}
+		return m.toMap(v, nil)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		if !needsMapping(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			value, err := m.toValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Key().Kind() != reflect.String || !needsMapping(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		result := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, err := m.toValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			result[iter.Key().String()] = value
+		}
+		return result, nil
+	default:
+		return v.Interface(), nil
+	}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	valuerType        = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// needsMapping 判断类型的值是否需要转换为 map，自定义了序列化方式的结构体保持原值
+func needsMapping(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		pt := reflect.PointerTo(t)
+		for _, it := range []reflect.Type{jsonMarshalerType, textMarshalerType, valuerType} {
+			if t.Implements(it) || pt.Implements(it) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// readField 按索引路径读取字段，路径中的嵌入指针为 nil 时返回 false
+func readField(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// FromMap 将 map 中的值写入结构体，键的规则与 ToMap 相同。基本类型按 mapx.GetString、GetInt、
+// GetFloat、GetBool 的规则转换，嵌套的 map 与 []interface{} 递归转换为结构体与切片，
+// 其他类型使用 RegisterConverter 注册的转换器。map 中不存在的键不修改对应字段
+// @source 源 map
+// @target 目标结构体的指针
+// @opts 转换的配置
+func FromMap(source map[string]interface{}, target interface{}, opts ...Option) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr {
+		return errors.New("target value can't a pointer type")
+	}
+	if targetValue.IsNil() {
+		return errors.New("target value can't be nil")
+	}
+	targetValue = NewPointer(targetValue)
+	if targetValue.Kind() != reflect.Struct {
+		return errors.New("target value must be a struct")
+	}
+	m := &mapper{options: newOptions(opts...)}
+	return m.fromMap(targetValue, source, m.options)
+}
+
+// fromMap 将 map 写入结构体，filter 不为空时按其中的白名单与黑名单过滤字段
+func (m *mapper) fromMap(dst reflect.Value, source map[string]interface{}, filter *options) error {
+	for _, f := range loadMapFields(dst.Type(), m.options.tag, m.options.keepEmbedded) {
+		if !filter.allow(f.field, f.key) {
+			continue
+		}
+		value, found := source[f.key]
+		if !found || m.options.omitEmpty && (value == nil || reflect.ValueOf(value).IsZero()) {
+			continue
+		}
+		dstField := fieldByIndex(dst, f.index)
+		if !dstField.IsValid() || !dstField.CanSet() {
+			continue
+		}
+		if err := m.fromValue(dstField, value); err != nil {
+			return fmt.Errorf("field %s: %w", f.field, err)
+		}
+	}
+	return nil
+}
+
+// fromValue 将 map 中的值写入可设置的 dst
+func (m *mapper) fromValue(dst reflect.Value, value interface{}) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	src := reflect.ValueOf(value)
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if fn, found := lookupConverter(src.Type(), dst.Type()); found {
+		result, err := fn(src)
+		if err != nil {
+			return err
+		}
+		dst.Set(result)
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		target := reflect.New(dst.Type().Elem())
+		if err := m.fromValue(target.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(target)
+		return nil
+	case reflect.String:
+		if s, ok := mapx.AsString(value); ok {
+			dst.SetString(s)
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := mapx.AsBool(value); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := mapx.AsInt(value); ok {
+			if dst.OverflowInt(int64(i)) {
+				return fmt.Errorf("value %v overflows %s", value, dst.Type())
+			}
+			dst.SetInt(int64(i))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if i, ok := mapx.AsInt(value); ok {
+			if i < 0 || dst.OverflowUint(uint64(i)) {
+				return fmt.Errorf("value %v overflows %s", value, dst.Type())
+			}
+			dst.SetUint(uint64(i))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := mapx.AsFloat(value); ok {
+			if dst.OverflowFloat(f) {
+				return fmt.Errorf("value %v overflows %s", value, dst.Type())
+			}
+			dst.SetFloat(f)
+			return nil
+		}
+	case reflect.Struct:
+		if nested, ok := value.(map[string]interface{}); ok {
+			return m.fromMap(dst, nested, nil)
+		}
+	case reflect.Slice:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			target := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				if err := m.fromValue(target.Index(i), src.Index(i).Interface()); err != nil {
+					return fmt.Errorf("index %d: %w", i, err)
+				}
+			}
+			dst.Set(target)
+			return nil
+		}
+	case reflect.Array:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			target := reflect.New(dst.Type()).Elem()
+			for i := 0; i < src.Len() && i < target.Len(); i++ {
+				if err := m.fromValue(target.Index(i), src.Index(i).Interface()); err != nil {
+					return fmt.Errorf("index %d: %w", i, err)
+				}
+			}
+			dst.Set(target)
+			return nil
+		}
+	case reflect.Map:
+		if src.Kind() == reflect.Map {
+			target := reflect.MakeMapWithSize(dst.Type(), src.Len())
+			iter := src.MapRange()
+			for iter.Next() {
+				key := reflect.New(dst.Type().Key()).Elem()
+				if err := m.fromValue(key, iter.Key().Interface()); err != nil {
+					return fmt.Errorf("key %v: %w", iter.Key(), err)
+				}
+				elem := reflect.New(dst.Type().Elem()).Elem()
+				if err := m.fromValue(elem, iter.Value().Interface()); err != nil {
+					return fmt.Errorf("key %v: %w", iter.Key(), err)
+				}
+				target.SetMapIndex(key, elem)
+			}
+			dst.Set(target)
+			return nil
+		}
+	}
+	// 通过基本类型中转使用已注册的转换器，如 JSON 中的数字转为 core.Long、字符串转为 decimal.Decimal
+	if ok, err := convertVia(dst, value); ok || err != nil {
+		return err
+	}
+	if ok, err := newCopier(nil).copyValue(dst, src); ok || err != nil {
+		return err
+	}
+	return fmt.Errorf("can not convert %T to %s", value, dst.Type())
+}
+
+var (
+	int64Type   = reflect.TypeOf(int64(0))
+	float64Type = reflect.TypeOf(float64(0))
+	stringType  = reflect.TypeOf("")
+)
+
+// convertVia 将值按 mapx 的规则转为 int64、float64 或 string 后，使用对应的转换器写入 dst，
+// 优先使用与值本身类型最接近的中转类型，避免浮点数被截断为整数
+func convertVia(dst reflect.Value, value interface{}) (bool, error) {
+	vias := []reflect.Type{int64Type, float64Type, stringType}
+	switch value.(type) {
+	case float32, float64:
+		vias = []reflect.Type{float64Type, stringType, int64Type}
+	case string:
+		vias = []reflect.Type{stringType, int64Type, float64Type}
+	}
+	for _, via := range vias {
+		fn, found := lookupConverter(via, dst.Type())
+		if !found {
+			continue
+		}
+		var src interface{}
+		var ok bool
+		switch via {
+		case int64Type:
+			var i int
+			i, ok = mapx.AsInt(value)
+			src = int64(i)
+		case float64Type:
+			src, ok = mapx.AsFloat(value)
+		default:
+			src, ok = mapx.AsString(value)
+		}
+		if ok {
+			return true, setConverted(dst, fn, reflect.ValueOf(src))
+		}
+	}
+	return false, nil
+}
+
+// setConverted 使用转换器转换后写入 dst
+func setConverted(dst reflect.Value, fn converterFunc, src reflect.Value) error {
+	result, err := fn(src)
+	if err != nil {
+		return err
+	}
+	dst.Set(result)
+	return nil
+}
@@ -0,0 +1,178 @@
+package bean
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minlib/go-util/core"
+	"github.com/shopspring/decimal"
+)
+
+type MapBase struct {
+	Id         int64         `json:"id"`
+	CreateTime core.DateTime `json:"createTime"`
+}
+
+type MapProfile struct {
+	Email string `json:"email"`
+	Level int8   `json:"level"`
+}
+
+type MapUser struct {
+	MapBase
+	Name     string          `json:"name"`
+	Password string          `json:"-"`
+	Nickname string          `json:"nickname,omitempty"`
+	Age      int             `json:"age"`
+	Enabled  bool            `json:"enabled"`
+	Balance  decimal.Decimal `json:"balance"`
+	ParentId core.Long       `json:"parentId"`
+	Profile  *MapProfile     `json:"profile"`
+	Roles    []MapProfile    `json:"roles"`
+	Tags     []string        `json:"tags"`
+	Remark   string          `db:"remark"`
+}
+
+func TestToMap(t *testing.T) {
+	now := time.Now()
+	user := MapUser{
+		MapBase:  MapBase{Id: 1, CreateTime: core.DateTime{Time: now}},
+		Name:     "admin",
+		Password: "123456",
+		Age:      18,
+		Balance:  decimal.RequireFromString("12.50"),
+		ParentId: core.NewLong(int64(9)),
+		Profile:  &MapProfile{Email: "admin@example.com", Level: 3},
+		Roles:    []MapProfile{{Email: "role@example.com"}},
+		Tags:     []string{"a", "b"},
+	}
+	m, err := ToMap(&user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"id":         int64(1),
+		"createTime": core.DateTime{Time: now},
+		"name":       "admin",
+		"age":        18,
+		"enabled":    false,
+		"balance":    user.Balance,
+		"parentId":   user.ParentId,
+		"profile":    map[string]interface{}{"email": "admin@example.com", "level": int8(3)},
+		"roles":      []interface{}{map[string]interface{}{"email": "role@example.com", "level": int8(0)}},
+		"tags":       []string{"a", "b"},
+		"Remark":     "",
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("ToMap() = %#v\nwant %#v", m, want)
+	}
+
+	m, err = ToMap(user, WithKeepEmbedded(), WithTag("db"), WithFields("MapBase", "remark"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[string]interface{}{
+		"MapBase": map[string]interface{}{"Id": int64(1), "CreateTime": core.DateTime{Time: now}},
+		"remark":  "",
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("ToMap(keep embedded) = %#v\nwant %#v", m, want)
+	}
+
+	// 局部更新：只输出非零值字段
+	m, err = ToMap(MapUser{Name: "new"}, WithOmitEmpty(), WithIgnoreFields("balance", "ParentId"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, map[string]interface{}{"name": "new"}) {
+		t.Errorf("ToMap(omit empty) = %#v", m)
+	}
+
+	if _, err := ToMap([]MapUser{}); err == nil {
+		t.Error("ToMap(slice) expected error")
+	}
+}
+
+func TestToMapCycle(t *testing.T) {
+	type CycleNode struct {
+		Name string
+		Next *CycleNode
+	}
+	node := &CycleNode{Name: "a"}
+	node.Next = &CycleNode{Name: "b", Next: node}
+	if _, err := ToMap(node); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("ToMap(cycle) error = %v, want cycle detected", err)
+	}
+	// 同一个指针出现在不同路径上不是循环引用
+	shared := &CycleNode{Name: "shared"}
+	if _, err := ToMap(struct{ A, B *CycleNode }{shared, shared}); err != nil {
+		t.Errorf("ToMap(shared) error = %v", err)
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	var source map[string]interface{}
+	data := `{"id":"1","createTime":"2026-10-18 10:00:00","name":"admin","password":"x","age":18.0,"enabled":"true",
+		"balance":"12.50","parentId":9,"profile":{"email":"admin@example.com","level":"3"},
+		"roles":[{"email":"role@example.com"}],"tags":["a",1],"Remark":"remark"}`
+	if err := json.Unmarshal([]byte(data), &source); err != nil {
+		t.Fatal(err)
+	}
+	user := MapUser{Password: "keep"}
+	if err := FromMap(source, &user, WithIgnoreFields("createTime")); err != nil {
+		t.Fatal(err)
+	}
+	if user.Id != 1 || user.Name != "admin" || user.Age != 18 || !user.Enabled || user.Remark != "remark" {
+		t.Errorf("FromMap() basic fields = %+v", user)
+	}
+	if user.Password != "keep" {
+		t.Errorf("Password = %q, want keep", user.Password)
+	}
+	if !user.Balance.Equal(decimal.RequireFromString("12.5")) || user.ParentId.Int64Def() != 9 {
+		t.Errorf("Balance = %s, ParentId = %s", user.Balance, user.ParentId)
+	}
+	if user.Profile == nil || user.Profile.Email != "admin@example.com" || user.Profile.Level != 3 {
+		t.Errorf("Profile = %+v", user.Profile)
+	}
+	if len(user.Roles) != 1 || user.Roles[0].Email != "role@example.com" {
+		t.Errorf("Roles = %+v", user.Roles)
+	}
+	if !reflect.DeepEqual(user.Tags, []string{"a", "1"}) {
+		t.Errorf("Tags = %v", user.Tags)
+	}
+
+	// 与 ToMap 互逆
+	m, err := ToMap(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var copied MapUser
+	if err := FromMap(m, &copied); err != nil {
+		t.Fatal(err)
+	}
+	user.Password = ""
+	if !reflect.DeepEqual(copied, user) {
+		t.Errorf("FromMap(ToMap()) = %+v\nwant %+v", copied, user)
+	}
+}
+
+func TestFromMapError(t *testing.T) {
+	var user MapUser
+	tests := []map[string]interface{}{
+		{"age": "abc"},
+		{"profile": map[string]interface{}{"level": 300}},
+		{"balance": "abc"},
+		{"roles": "abc"},
+	}
+	for _, source := range tests {
+		if err := FromMap(source, &user); err == nil {
+			t.Errorf("FromMap(%v) expected error", source)
+		}
+	}
+	if err := FromMap(nil, user); err == nil {
+		t.Error("FromMap(non-pointer) expected error")
+	}
+}
@@ -0,0 +1,99 @@
+package bean
+
+// Option 配置复制行为的可选项
+type Option func(*options)
+
+// options 复制的配置
+type options struct {
+	fields       map[string]struct{} // 只复制的字段（白名单），仅作用于最外层的结构体
+	ignores      map[string]struct{} // 不复制的字段（黑名单），仅作用于最外层的结构体
+	omitEmpty    bool                // 跳过源对象中的零值字段
+	tag          string              // ToMap 与 FromMap 使用的标签名称
+	keepEmbedded bool                // ToMap 与 FromMap 保留嵌入结构体的层级
+	strict       bool                // 严格模式，存在诊断问题时返回错误
+}
+
+// newOptions 创建复制的配置
+func newOptions(opts ...Option) *options {
+	o := &options{tag: "json"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithFields 只复制指定的字段，字段名可以是源字段的名称或 bean 标签中的 name
+func WithFields(fields ...string) Option {
+	return func(o *options) {
+		if len(fields) == 0 {
+			return
+		}
+		if o.fields == nil {
+			o.fields = make(map[string]struct{}, len(fields))
+		}
+		for _, field := range fields {
+			o.fields[field] = struct{}{}
+		}
+	}
+}
+
+// WithIgnoreFields 不复制指定的字段，字段名可以是源字段的名称或 bean 标签中的 name
+func WithIgnoreFields(fields ...string) Option {
+	return func(o *options) {
+		if len(fields) == 0 {
+			return
+		}
+		if o.ignores == nil {
+			o.ignores = make(map[string]struct{}, len(fields))
+		}
+		for _, field := range fields {
+			o.ignores[field] = struct{}{}
+		}
+	}
+}
+
+// WithOmitEmpty 跳过源对象中的零值字段（包括 nil 指针），用于只更新客户端传入字段的局部更新
+func WithOmitEmpty() Option {
+	return func(o *options) {
+		o.omitEmpty = true
+	}
+}
+
+// WithTag 设置 ToMap 与 FromMap 使用的标签名称，默认为 json，标签格式与 json 标签相同
+func WithTag(tag string) Option {
+	return func(o *options) {
+		o.tag = tag
+	}
+}
+
+// WithKeepEmbedded ToMap 与 FromMap 保留嵌入结构体的层级，嵌入结构体作为以类型名称为键的嵌套 map，
+// 默认与 encoding/json 一致，将嵌入结构体的字段展开到外层
+func WithKeepEmbedded() Option {
+	return func(o *options) {
+		o.keepEmbedded = true
+	}
+}
+
+// allow 判断最外层结构体的字段是否需要处理
+// @field 字段名称
+// @name 映射名称或 map 的键
+func (o *options) allow(field, name string) bool {
+	if o == nil {
+		return true
+	}
+	if len(o.fields) > 0 {
+		_, byName := o.fields[field]
+		_, byTag := o.fields[name]
+		if !byName && !byTag {
+			return false
+		}
+	}
+	if len(o.ignores) > 0 {
+		_, byName := o.ignores[field]
+		_, byTag := o.ignores[name]
+		if byName || byTag {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+package bean
+
+import (
+	"fmt"
+	"testing"
+)
+
+type TagUser struct {
+	Id       int64
+	Name     string `bean:"name=UserName"`
+	Password string `bean:"-"`
+	Nickname string `bean:"omitempty"`
+	Remark   string `bean:"ignore"`
+	Age      int
+	Email    *string
+}
+
+type TagBase struct {
+	Id int64
+}
+
+type TagUserDTO struct {
+	*TagBase
+	UserName string
+	Password string
+	Nickname string
+	Remark   string
+	Age      int
+	Email    *string
+}
+
+type TagUserForm struct {
+	Account string `bean:"name=UserName"`
+	Age     int    `bean:"omitempty"`
+}
+
+func TestCopyTag(t *testing.T) {
+	source := TagUser{Id: 1, Name: "admin", Password: "123456", Remark: "remark", Age: 18}
+	target := TagUserDTO{Nickname: "old", Remark: "old"}
+	if err := Copy(source, &target); err != nil {
+		t.Fatal(err)
+	}
+	if target.TagBase == nil || target.Id != 1 {
+		t.Errorf("embedded field was not copied: %+v", target.TagBase)
+	}
+	if target.UserName != "admin" {
+		t.Errorf("UserName = %q, want admin", target.UserName)
+	}
+	if target.Password != "" {
+		t.Errorf("Password = %q, want empty", target.Password)
+	}
+	if target.Nickname != "old" {
+		t.Errorf("Nickname = %q, want old", target.Nickname)
+	}
+	if target.Remark != "old" {
+		t.Errorf("Remark = %q, want old", target.Remark)
+	}
+
+	// 目标字段的标签同样生效
+	form, err := CopyTo[TagUserForm](TagUserDTO{UserName: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Account != "admin" {
+		t.Errorf("Account = %q, want admin", form.Account)
+	}
+	form.Age = 20
+	if err := Copy(TagUserDTO{UserName: "root"}, &form); err != nil {
+		t.Fatal(err)
+	}
+	if form.Account != "root" || form.Age != 20 {
+		t.Errorf("form = %+v, want Account root and Age 20", form)
+	}
+}
+
+func TestCopyWithOptions(t *testing.T) {
+	email := "admin@example.com"
+	source := TagUser{Id: 1, Name: "admin", Age: 18, Email: &email}
+
+	target := TagUserDTO{}
+	if err := CopyWithOptions(source, &target, WithIgnoreFields("Id", "UserName")); err != nil {
+		t.Fatal(err)
+	}
+	if target.TagBase != nil || target.UserName != "" || target.Age != 18 || target.Email == nil {
+		t.Errorf("ignore fields failed: %+v", target)
+	}
+
+	target = TagUserDTO{}
+	if err := CopyWithOptions(source, &target, WithFields("Name", "Age", "Email"), WithIgnoreFields("Email")); err != nil {
+		t.Fatal(err)
+	}
+	if target.TagBase != nil || target.UserName != "admin" || target.Age != 18 || target.Email != nil {
+		t.Errorf("fields with ignore fields failed: %+v", target)
+	}
+
+	// 局部更新：只复制非零值的字段
+	old := "old@example.com"
+	target = TagUserDTO{UserName: "old", Age: 20, Email: &old}
+	if err := CopyWithOptions(TagUser{Name: "new"}, &target, WithOmitEmpty()); err != nil {
+		t.Fatal(err)
+	}
+	if target.UserName != "new" || target.Age != 20 || target.Email != &old {
+		t.Errorf("omit empty failed: %+v", target)
+	}
+
+	dto, err := CopyToWithOptions[TagUserDTO](&source, WithFields("Age"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dto.Age != 18 || dto.UserName != "" {
+		t.Errorf("CopyToWithOptions failed: %+v", dto)
+	}
+}
+
+func TestCopyWithOptionsPlanCache(t *testing.T) {
+	countPlans := func() int {
+		n := 0
+		plans.Range(func(_, _ any) bool {
+			n++
+			return true
+		})
+		return n
+	}
+	source := TagUser{Id: 1, Name: "admin", Age: 18}
+	if err := CopyWithOptions(source, &TagUserDTO{}, WithFields("Age")); err != nil {
+		t.Fatal(err)
+	}
+	n := countPlans()
+	// 不同的字段过滤条件复用同一个未过滤的计划，缓存不随过滤条件增长
+	for i := 0; i < 50; i++ {
+		target := TagUserDTO{}
+		if err := CopyWithOptions(source, &target, WithFields("Age", fmt.Sprintf("field%d", i))); err != nil {
+			t.Fatal(err)
+		}
+		if target.Age != 18 || target.UserName != "" {
+			t.Fatalf("CopyWithOptions() = %+v", target)
+		}
+	}
+	if got := countPlans(); got != n {
+		t.Errorf("plans = %d after filtered copies, want %d", got, n)
+	}
+}
+
+func TestCopyWithOptionsNested(t *testing.T) {
+	type Inner struct {
+		Name  string
+		Value int
+	}
+	type Outer struct {
+		Inner *Inner
+		Items []Inner
+	}
+	target := Outer{Inner: &Inner{Name: "old", Value: 1}}
+	source := Outer{Inner: &Inner{Name: "new"}, Items: []Inner{{Value: 2}}}
+	if err := CopyWithOptions(source, &target, WithOmitEmpty()); err != nil {
+		t.Fatal(err)
+	}
+	if target.Inner.Name != "new" || target.Inner.Value != 1 {
+		t.Errorf("Inner = %+v", target.Inner)
+	}
+	if len(target.Items) != 1 || target.Items[0].Value != 2 {
+		t.Errorf("Items = %+v", target.Items)
+	}
+}
@@ -0,0 +1,130 @@
+package bean
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrStrictCopy 严格模式下复制存在未匹配、未赋值或失败的字段
+var ErrStrictCopy = errors.New("strict copy failed")
+
+// CopyReport 复制的诊断报告，嵌套字段使用 . 分隔的路径表示，如 Address.City，
+// 切片、数组与映射中的元素不区分下标，同一路径只报告一次
+type CopyReport struct {
+	Unmatched []string       // 源对象中没有对应目标字段的字段
+	Unset     []string       // 目标对象中没有对应源字段、不会被赋值的字段
+	Failed    []FieldFailure // 类型不匹配或转换失败的字段
+}
+
+// FieldFailure 复制失败的字段
+type FieldFailure struct {
+	Field  string       // 字段路径
+	Source reflect.Type // 源字段类型
+	Target reflect.Type // 目标字段类型
+	Err    error        // 转换器返回的错误，类型不匹配时为 nil
+}
+
+// String 返回失败原因
+func (f FieldFailure) String() string {
+	if f.Err != nil {
+		return fmt.Sprintf("%s (%s -> %s: %v)", f.Field, f.Source, f.Target, f.Err)
+	}
+	return fmt.Sprintf("%s (%s -> %s)", f.Field, f.Source, f.Target)
+}
+
+// HasIssues 是否存在未匹配、未赋值或失败的字段
+func (r *CopyReport) HasIssues() bool {
+	return r != nil && (len(r.Unmatched) > 0 || len(r.Unset) > 0 || len(r.Failed) > 0)
+}
+
+// String 返回报告的摘要
+func (r *CopyReport) String() string {
+	if !r.HasIssues() {
+		return "no issues"
+	}
+	var parts []string
+	if len(r.Unmatched) > 0 {
+		parts = append(parts, "unmatched source fields: "+strings.Join(r.Unmatched, ", "))
+	}
+	if len(r.Unset) > 0 {
+		parts = append(parts, "unset target fields: "+strings.Join(r.Unset, ", "))
+	}
+	if len(r.Failed) > 0 {
+		failed := make([]string, len(r.Failed))
+		for i, f := range r.Failed {
+			failed[i] = f.String()
+		}
+		parts = append(parts, "failed fields: "+strings.Join(failed, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// reporter 收集复制过程中的诊断信息
+type reporter struct {
+	report CopyReport
+	seen   map[string]struct{} // 已报告的类别与路径
+}
+
+// newReporter 创建诊断信息收集器
+func newReporter() *reporter {
+	return &reporter{seen: make(map[string]struct{})}
+}
+
+// once 同一类别与路径只报告一次
+func (r *reporter) once(kind, path string) bool {
+	key := kind + ":" + path
+	if _, found := r.seen[key]; found {
+		return false
+	}
+	r.seen[key] = struct{}{}
+	return true
+}
+
+// structFields 记录结构体中未匹配与未赋值的字段
+func (r *reporter) structFields(path string, plan *structPlan) {
+	for _, ref := range plan.unmatched {
+		if p := joinPath(path, ref.field); r.once("unmatched", p) {
+			r.report.Unmatched = append(r.report.Unmatched, p)
+		}
+	}
+	for _, ref := range plan.unset {
+		if p := joinPath(path, ref.field); r.once("unset", p) {
+			r.report.Unset = append(r.report.Unset, p)
+		}
+	}
+}
+
+// failed 记录复制失败的字段
+func (r *reporter) failed(path string, dst, src reflect.Value, err error) {
+	if r.once("failed", path) {
+		r.report.Failed = append(r.report.Failed, FieldFailure{Field: path, Source: src.Type(), Target: dst.Type(), Err: err})
+	}
+}
+
+// joinPath 拼接字段路径
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// WithStrict 严格模式，源对象存在未匹配的字段、目标对象存在未赋值的字段或字段复制失败时返回 ErrStrictCopy，
+// 用于在测试中发现 DTO 字段重命名等问题。零值被跳过或被白名单、黑名单过滤的字段不视为问题
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// CopyWithReport 按配置将源对象转为目标对象，同时返回复制的诊断报告
+// @source 源对象
+// @target 目标对象
+// @opts 复制的配置
+func CopyWithReport(source, target interface{}, opts ...Option) (*CopyReport, error) {
+	r := newReporter()
+	err := copyWithReporter(source, target, newOptions(opts...), r)
+	return &r.report, err
+}
@@ -0,0 +1,105 @@
+package bean
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ReportAddress struct {
+	City string
+	Zip  string
+}
+
+type ReportAddressDTO struct {
+	City     string
+	Province string
+}
+
+type ReportUser struct {
+	Name     string
+	Age      string
+	Password string `bean:"-"`
+	Address  ReportAddress
+	Others   []ReportAddress
+	Phone    string
+}
+
+type ReportUserDTO struct {
+	Name    string
+	Age     int
+	Address ReportAddressDTO
+	Others  []ReportAddressDTO
+	Mobile  string
+}
+
+func TestCopyWithReport(t *testing.T) {
+	source := ReportUser{
+		Name:    "admin",
+		Age:     "18",
+		Address: ReportAddress{City: "Shenzhen"},
+		Others:  []ReportAddress{{City: "A"}, {City: "B"}},
+	}
+	var target ReportUserDTO
+	report, err := CopyWithReport(source, &target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.Name != "admin" || target.Address.City != "Shenzhen" || len(target.Others) != 2 {
+		t.Errorf("target = %+v", target)
+	}
+	if want := []string{"Phone", "Address.Zip", "Others.Zip"}; !reflect.DeepEqual(report.Unmatched, want) {
+		t.Errorf("Unmatched = %v, want %v", report.Unmatched, want)
+	}
+	if want := []string{"Mobile", "Address.Province", "Others.Province"}; !reflect.DeepEqual(report.Unset, want) {
+		t.Errorf("Unset = %v, want %v", report.Unset, want)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Field != "Age" || report.Failed[0].Target.Kind() != reflect.Int {
+		t.Errorf("Failed = %v", report.Failed)
+	}
+	if !report.HasIssues() || !strings.Contains(report.String(), "failed fields: Age (string -> int)") {
+		t.Errorf("String() = %s", report.String())
+	}
+
+	// 白名单过滤的字段不报告
+	report, err = CopyWithReport(source, &target, WithFields("Name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.HasIssues() {
+		t.Errorf("report with fields = %s", report)
+	}
+}
+
+func TestCopyStrict(t *testing.T) {
+	var target ReportUserDTO
+	err := CopyWithOptions(ReportUser{Name: "admin"}, &target, WithStrict())
+	if !errors.Is(err, ErrStrictCopy) {
+		t.Fatalf("CopyWithOptions(strict) error = %v, want ErrStrictCopy", err)
+	}
+	if err := CopyWithOptions([]ReportUser{{Name: "admin"}}, &[]ReportUserDTO{}, WithStrict()); !errors.Is(err, ErrStrictCopy) {
+		t.Errorf("CopyWithOptions(strict slice) error = %v, want ErrStrictCopy", err)
+	}
+	if err := CopyWithOptions(Item{Name: "a"}, &ItemDTO{}, WithStrict()); err != nil {
+		t.Errorf("CopyWithOptions(strict matched) error = %v", err)
+	}
+}
+
+func TestCopySliceError(t *testing.T) {
+	source := []ConvertDTO{{Amount: "1"}, {Amount: "abc"}}
+	var target []ConvertEntity
+	err := Copy(source, &target)
+	if err == nil || !strings.Contains(err.Error(), "copy index 1") {
+		t.Errorf("Copy() error = %v, want error at index 1", err)
+	}
+
+	// nil 项保持零值
+	var items []*ItemDTO
+	if err := Copy([]*Item{{Name: "a"}, nil}, &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Name != "a" || items[1] != nil {
+		t.Errorf("items = %v", items)
+	}
+}
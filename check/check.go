@@ -1,28 +1,21 @@
 package check
 
-import "regexp"
-
-// CheckMobile check mobile number
+// CheckMobile check mobile number by rule RuleMobile
 func CheckMobile(mobile string) bool {
-	return Check("^1[3-9]{1}[0-9]{9}$", mobile)
-}
-
-// CheckIdCard check id card
-func CheckIdCard(idCard string) bool {
-	return Check("^[1-9][0-9]{16}[0-9xX]$", idCard)
+	return CheckRule(RuleMobile, mobile)
 }
 
-// CheckUserName check user name
+// CheckUserName check user name by rule RuleUserName
 func CheckUserName(username string) bool {
-	return Check("^[a-zA-Z]+[a-zA-Z0-9_-]{4,17}$", username)
+	return CheckRule(RuleUserName, username)
 }
 
-// CheckPassword check password
+// CheckPassword check password by rule RulePassword
 func CheckPassword(password string) bool {
-	return Check("^[a-zA-Z0-9_-|@.]{5,18}$", password)
+	return CheckRule(RulePassword, password)
 }
 
-func Check(pattern, s string) bool {
-	matched, _ := regexp.MatchString(pattern, s)
-	return matched
+// CheckEmail check email by ValidateEmail
+func CheckEmail(email string) bool {
+	return ValidateEmail(email) == nil
 }
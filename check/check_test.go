@@ -29,6 +29,27 @@ func TestCheckIdCard(t *testing.T) {
 	fmt.Println(CheckIdCard("040421200001015333"))  // false
 }
 
+func TestCheckEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{"admin@example.com", true},
+		{"first.last+tag@mail.example.com.cn", true},
+		{"admin@example", false},
+		{"admin.example.com", false},
+		{"admin@@example.com", false},
+		{"a..b@example.com", false},
+		{"admin@[192.168.0.1]", true},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := CheckEmail(tt.email); got != tt.want {
+			t.Errorf("CheckEmail(%q) = %v, want %v", tt.email, got, tt.want)
+		}
+	}
+}
+
 func TestCheckUserName(t *testing.T) {
 	fmt.Println(rand.Float32())
 	fmt.Println(rand.Float64())
@@ -0,0 +1,160 @@
+package check
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrIdCardLength 身份证号码长度错误
+	ErrIdCardLength = errors.New("id card must be 15 or 18 characters")
+	// ErrIdCardFormat 身份证号码包含非法字符
+	ErrIdCardFormat = errors.New("id card contains invalid characters")
+	// ErrIdCardRegion 身份证号码的地区码错误
+	ErrIdCardRegion = errors.New("id card region code is invalid")
+	// ErrIdCardBirthday 身份证号码的出生日期错误
+	ErrIdCardBirthday = errors.New("id card birthday is invalid")
+	// ErrIdCardChecksum 身份证号码的校验码错误
+	ErrIdCardChecksum = errors.New("id card checksum is invalid")
+)
+
+// Gender 性别
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+// idCardProvinces 身份证号码前两位对应的省级行政区（GB/T 2260）
+var idCardProvinces = map[string]string{
+	"11": "北京市", "12": "天津市", "13": "河北省", "14": "山西省", "15": "内蒙古自治区",
+	"21": "辽宁省", "22": "吉林省", "23": "黑龙江省",
+	"31": "上海市", "32": "江苏省", "33": "浙江省", "34": "安徽省", "35": "福建省", "36": "江西省", "37": "山东省",
+	"41": "河南省", "42": "湖北省", "43": "湖南省", "44": "广东省", "45": "广西壮族自治区", "46": "海南省",
+	"50": "重庆市", "51": "四川省", "52": "贵州省", "53": "云南省", "54": "西藏自治区",
+	"61": "陕西省", "62": "甘肃省", "63": "青海省", "64": "宁夏回族自治区", "65": "新疆维吾尔自治区",
+	"71": "台湾省", "81": "香港特别行政区", "82": "澳门特别行政区", "83": "台湾省",
+}
+
+// idCardWeights 18位身份证号码前17位的加权因子（ISO 7064 MOD 11-2）
+var idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+// idCardCheckDigits 加权和模11后对应的校验码
+var idCardCheckDigits = [11]byte{'1', '0', 'X', '9', '8', '7', '6', '5', '4', '3', '2'}
+
+// IdCard 解析后的身份证信息
+type IdCard struct {
+	Number   string    // 18位身份证号码，15位号码会升级为18位，末位 x 统一为大写
+	Region   string    // 6位地区码
+	Province string    // 省级行政区名称
+	Birthday time.Time // 出生日期
+	Gender   Gender    // 性别
+}
+
+// Age 计算在指定时间的周岁年龄
+func (c *IdCard) Age(now time.Time) int {
+	age := now.Year() - c.Birthday.Year()
+	if now.Month() < c.Birthday.Month() || now.Month() == c.Birthday.Month() && now.Day() < c.Birthday.Day() {
+		age--
+	}
+	return age
+}
+
+// CheckIdCard check id card by GB 11643: region code, birthday and checksum, legacy 15-digit cards are accepted
+func CheckIdCard(idCard string) bool {
+	_, err := ParseIdCard(idCard)
+	return err == nil
+}
+
+// ParseIdCard 校验并解析身份证号码，15位旧号码会先升级为18位
+func ParseIdCard(idCard string) (*IdCard, error) {
+	var number string
+	switch len(idCard) {
+	case 15:
+		upgraded, err := UpgradeIdCard(idCard)
+		if err != nil {
+			return nil, err
+		}
+		number = upgraded
+	case 18:
+		if !isDigits(idCard[:17]) {
+			return nil, ErrIdCardFormat
+		}
+		last := idCard[17]
+		if last == 'x' {
+			last = 'X'
+		}
+		if last != 'X' && (last < '0' || last > '9') {
+			return nil, ErrIdCardFormat
+		}
+		number = idCard[:17] + string(last)
+		if IdCardCheckDigit(number[:17]) != last {
+			return nil, ErrIdCardChecksum
+		}
+	default:
+		return nil, ErrIdCardLength
+	}
+	province, found := idCardProvinces[number[:2]]
+	if !found {
+		return nil, ErrIdCardRegion
+	}
+	birthday, err := parseIdCardBirthday(number[6:14])
+	if err != nil {
+		return nil, err
+	}
+	gender := GenderFemale
+	if (number[16]-'0')%2 == 1 {
+		gender = GenderMale
+	}
+	return &IdCard{
+		Number:   number,
+		Region:   number[:6],
+		Province: province,
+		Birthday: birthday,
+		Gender:   gender,
+	}, nil
+}
+
+// UpgradeIdCard 将15位旧身份证号码升级为18位：出生年份补全为19xx并追加校验码
+func UpgradeIdCard(idCard string) (string, error) {
+	if len(idCard) != 15 {
+		return "", ErrIdCardLength
+	}
+	if !isDigits(idCard) {
+		return "", ErrIdCardFormat
+	}
+	first17 := idCard[:6] + "19" + idCard[6:]
+	return first17 + string(IdCardCheckDigit(first17)), nil
+}
+
+// IdCardCheckDigit 计算18位身份证号码前17位对应的校验码（ISO 7064 MOD 11-2），参数无效时返回 0
+func IdCardCheckDigit(first17 string) byte {
+	if len(first17) != 17 || !isDigits(first17) {
+		return 0
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += int(first17[i]-'0') * idCardWeights[i]
+	}
+	return idCardCheckDigits[sum%11]
+}
+
+// parseIdCardBirthday 解析 yyyyMMdd 格式的出生日期，日期必须真实存在且不晚于今天
+func parseIdCardBirthday(s string) (time.Time, error) {
+	birthday, err := time.ParseInLocation("20060102", s, time.Local)
+	if err != nil || birthday.Year() < 1800 || birthday.After(time.Now()) {
+		return time.Time{}, ErrIdCardBirthday
+	}
+	return birthday, nil
+}
+
+// isDigits 判断字符串是否全部为数字
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
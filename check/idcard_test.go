@@ -0,0 +1,76 @@
+package check
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseIdCard(t *testing.T) {
+	tests := []struct {
+		idCard   string
+		number   string
+		province string
+		birthday string
+		gender   Gender
+		err      error
+	}{
+		{"11010519491231002X", "11010519491231002X", "北京市", "1949-12-31", GenderFemale, nil},
+		{"11010519491231002x", "11010519491231002X", "北京市", "1949-12-31", GenderFemale, nil},
+		{"440524188001010014", "440524188001010014", "广东省", "1880-01-01", GenderMale, nil},
+		{"130503670401001", "130503196704010016", "河北省", "1967-04-01", GenderMale, nil},
+		{"440421200001015333", "", "", "", "", ErrIdCardChecksum},
+		{"44042120000101533", "", "", "", "", ErrIdCardLength},
+		{"4404212X0001015338", "", "", "", "", ErrIdCardFormat},
+		{"44042120000101533Y", "", "", "", "", ErrIdCardFormat},
+		{"990421200001015334", "", "", "", "", ErrIdCardRegion},
+		{"440421200002305333", "", "", "", "", ErrIdCardBirthday},
+		{"44042130000101533X", "", "", "", "", ErrIdCardBirthday},
+	}
+	for _, tt := range tests {
+		// 非法日期与地区码的用例需要正确的校验码，才能校验到对应的错误
+		idCard := tt.idCard
+		if tt.err == ErrIdCardRegion || tt.err == ErrIdCardBirthday {
+			idCard = idCard[:17] + string(IdCardCheckDigit(idCard[:17]))
+		}
+		c, err := ParseIdCard(idCard)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseIdCard(%s) error = %v, want %v", idCard, err, tt.err)
+			continue
+		}
+		if err != nil {
+			if CheckIdCard(idCard) {
+				t.Errorf("CheckIdCard(%s) = true, want false", idCard)
+			}
+			continue
+		}
+		if c.Number != tt.number || c.Province != tt.province || c.Birthday.Format("2006-01-02") != tt.birthday || c.Gender != tt.gender {
+			t.Errorf("ParseIdCard(%s) = %+v", idCard, c)
+		}
+		if c.Region != tt.number[:6] || !CheckIdCard(idCard) {
+			t.Errorf("ParseIdCard(%s) region = %s", idCard, c.Region)
+		}
+	}
+}
+
+func TestUpgradeIdCard(t *testing.T) {
+	if got, err := UpgradeIdCard("130503670401001"); err != nil || got != "130503196704010016" {
+		t.Errorf("UpgradeIdCard() = %s, %v", got, err)
+	}
+	if _, err := UpgradeIdCard("13050367040100X"); !errors.Is(err, ErrIdCardFormat) {
+		t.Errorf("UpgradeIdCard(invalid) error = %v", err)
+	}
+}
+
+func TestIdCardAge(t *testing.T) {
+	c, err := ParseIdCard("11010519491231002X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age := c.Age(time.Date(2026, 12, 30, 0, 0, 0, 0, time.Local)); age != 76 {
+		t.Errorf("Age() = %d, want 76", age)
+	}
+	if age := c.Age(time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local)); age != 77 {
+		t.Errorf("Age() = %d, want 77", age)
+	}
+}
@@ -0,0 +1,145 @@
+package check
+
+import "strings"
+
+// creditCodeChars 统一社会信用代码的字符集（GB 32100，不使用 I、O、Z、S、V），下标即字符的数值
+const creditCodeChars = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+
+// creditCodeWeights 统一社会信用代码前17位的加权因子
+var creditCodeWeights = [17]int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+
+// plateProvinces 车牌的省份简称
+const plateProvinces = "京津沪渝冀豫云辽黑湘皖鲁新苏浙赣鄂桂甘晋蒙陕吉闽贵粤青藏川宁琼"
+
+// CheckCreditCode check unified social credit code by GB 32100 checksum
+func CheckCreditCode(code string) bool {
+	if len(code) != 18 || !CheckRule(RuleCreditCode, code) {
+		return false
+	}
+	return CreditCodeCheckDigit(code[:17]) == code[17]
+}
+
+// CreditCodeCheckDigit 计算统一社会信用代码前17位对应的校验码，参数无效时返回 0
+func CreditCodeCheckDigit(first17 string) byte {
+	if len(first17) != 17 {
+		return 0
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		v := strings.IndexByte(creditCodeChars, first17[i])
+		if v < 0 {
+			return 0
+		}
+		sum += v * creditCodeWeights[i]
+	}
+	return creditCodeChars[(31-sum%31)%31]
+}
+
+// BankCardBrand 银行卡的卡组织
+type BankCardBrand string
+
+const (
+	BankCardUnknown    BankCardBrand = ""
+	BankCardUnionPay   BankCardBrand = "unionpay"
+	BankCardVisa       BankCardBrand = "visa"
+	BankCardMasterCard BankCardBrand = "mastercard"
+	BankCardAmex       BankCardBrand = "amex"
+	BankCardJCB        BankCardBrand = "jcb"
+)
+
+// bankCardLengths 各卡组织允许的卡号长度，未知卡组织按国内借记卡允许16-19位
+var bankCardLengths = map[BankCardBrand][]int{
+	BankCardUnknown:    {16, 17, 18, 19},
+	BankCardUnionPay:   {16, 17, 18, 19},
+	BankCardVisa:       {13, 16, 19},
+	BankCardMasterCard: {16},
+	BankCardAmex:       {15},
+	BankCardJCB:        {16, 17, 18, 19},
+}
+
+// CheckBankCard check bank card number by Luhn checksum and the length allowed by its BIN
+func CheckBankCard(card string) bool {
+	if !isDigits(card) || !checkLuhn(card) {
+		return false
+	}
+	for _, length := range bankCardLengths[GetBankCardBrand(card)] {
+		if len(card) == length {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBankCardBrand 根据卡号的 BIN（发卡行识别码）判断卡组织
+func GetBankCardBrand(card string) BankCardBrand {
+	if len(card) < 4 || !isDigits(card[:4]) {
+		return BankCardUnknown
+	}
+	prefix2 := int(card[0]-'0')*10 + int(card[1]-'0')
+	prefix4 := prefix2*100 + int(card[2]-'0')*10 + int(card[3]-'0')
+	switch {
+	case prefix2 == 62:
+		return BankCardUnionPay
+	case card[0] == '4':
+		return BankCardVisa
+	case prefix2 >= 51 && prefix2 <= 55, prefix4 >= 2221 && prefix4 <= 2720:
+		return BankCardMasterCard
+	case prefix2 == 34 || prefix2 == 37:
+		return BankCardAmex
+	case prefix4 >= 3528 && prefix4 <= 3589:
+		return BankCardJCB
+	default:
+		return BankCardUnknown
+	}
+}
+
+// checkLuhn 校验数字串的 Luhn 校验位（最后一位）
+func checkLuhn(digits string) bool {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// CheckPassport check Chinese passport number, such as E12345678, EA1234567 or G12345678
+func CheckPassport(passport string) bool {
+	return CheckRule(RulePassport, passport)
+}
+
+// CheckHKMacaoPermit check exit-entry permit for travelling to and from Hong Kong and Macao, such as C12345678 or CA1234567
+func CheckHKMacaoPermit(permit string) bool {
+	return CheckRule(RuleHKMacaoPermit, permit)
+}
+
+// CheckHomeReturnPermit check mainland travel permit for Hong Kong and Macao residents, such as H12345678 or M1234567801
+func CheckHomeReturnPermit(permit string) bool {
+	return CheckRule(RuleHomeReturnPermit, permit)
+}
+
+// CheckLicensePlate check license plate number, including new energy plates
+func CheckLicensePlate(plate string) bool {
+	return CheckRule(RuleLicensePlate, plate) || CheckNewEnergyPlate(plate)
+}
+
+// CheckNewEnergyPlate check new energy license plate number, small cars such as 粤BD12345 and large cars such as 粤B12345D
+func CheckNewEnergyPlate(plate string) bool {
+	return CheckRule(RuleNewEnergyPlate, plate)
+}
+
+// CheckPostalCode check Chinese postal code
+func CheckPostalCode(code string) bool {
+	return CheckRule(RulePostalCode, code)
+}
+
+// CheckLandline check landline number with area code and optional extension, such as 010-62345678 or 0755-2345678-123
+func CheckLandline(landline string) bool {
+	return CheckRule(RuleLandline, landline)
+}
@@ -0,0 +1,93 @@
+package check
+
+import "testing"
+
+func TestCheckCreditCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"91350100M000100Y43", true},
+		{"91110000600037341L", true},
+		{"91440300MA5DC8DX0G", true},
+		{"91350100M000100Y44", false},
+		{"91350100M000100Y4", false},
+		{"91350100M000100I43", false},
+		{"9135010OM000100Y43", false},
+	}
+	for _, tt := range tests {
+		if got := CheckCreditCode(tt.code); got != tt.want {
+			t.Errorf("CheckCreditCode(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestCheckBankCard(t *testing.T) {
+	tests := []struct {
+		card  string
+		brand BankCardBrand
+		want  bool
+	}{
+		{"6200000000000005", BankCardUnionPay, true},
+		{"6205500000000000004", BankCardUnionPay, true},
+		{"4111111111111111", BankCardVisa, true},
+		{"5555555555554444", BankCardMasterCard, true},
+		{"2223003122003222", BankCardMasterCard, true},
+		{"378282246310005", BankCardAmex, true},
+		{"3530111333300000", BankCardJCB, true},
+		{"4111111111111112", BankCardVisa, false},
+		{"37828224631000", BankCardAmex, false},
+		{"555555555555444", BankCardMasterCard, false},
+		{"62000000000000a5", BankCardUnionPay, false},
+	}
+	for _, tt := range tests {
+		if got := GetBankCardBrand(tt.card); got != tt.brand {
+			t.Errorf("GetBankCardBrand(%s) = %q, want %q", tt.card, got, tt.brand)
+		}
+		if got := CheckBankCard(tt.card); got != tt.want {
+			t.Errorf("CheckBankCard(%s) = %v, want %v", tt.card, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDocuments(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(string) bool
+		value string
+		want  bool
+	}{
+		{"passport", CheckPassport, "E12345678", true},
+		{"passport", CheckPassport, "EA1234567", true},
+		{"passport", CheckPassport, "G12345678", true},
+		{"passport", CheckPassport, "EI1234567", false},
+		{"passport", CheckPassport, "E1234567", false},
+		{"hk macao permit", CheckHKMacaoPermit, "C12345678", true},
+		{"hk macao permit", CheckHKMacaoPermit, "CA1234567", true},
+		{"hk macao permit", CheckHKMacaoPermit, "D12345678", false},
+		{"home return permit", CheckHomeReturnPermit, "H12345678", true},
+		{"home return permit", CheckHomeReturnPermit, "M1234567801", true},
+		{"home return permit", CheckHomeReturnPermit, "H123456789", false},
+		{"license plate", CheckLicensePlate, "粤B12345", true},
+		{"license plate", CheckLicensePlate, "京A1234学", true},
+		{"license plate", CheckLicensePlate, "粤BD12345", true},
+		{"license plate", CheckLicensePlate, "粤BI2345", false},
+		{"license plate", CheckLicensePlate, "港B12345", false},
+		{"new energy plate", CheckNewEnergyPlate, "粤BD12345", true},
+		{"new energy plate", CheckNewEnergyPlate, "粤B12345F", true},
+		{"new energy plate", CheckNewEnergyPlate, "粤B12345", false},
+		{"postal code", CheckPostalCode, "518000", true},
+		{"postal code", CheckPostalCode, "918000", false},
+		{"postal code", CheckPostalCode, "51800", false},
+		{"landline", CheckLandline, "010-62345678", true},
+		{"landline", CheckLandline, "0755-2345678", true},
+		{"landline", CheckLandline, "075523456789-8001", true},
+		{"landline", CheckLandline, "010-6234567", false},
+		{"landline", CheckLandline, "12345678", false},
+	}
+	for _, tt := range tests {
+		if got := tt.check(tt.value); got != tt.want {
+			t.Errorf("%s(%s) = %v, want %v", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
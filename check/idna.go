@@ -0,0 +1,18 @@
+package check
+
+import (
+	"golang.org/x/net/idna"
+)
+
+// IDNToASCII 按 IDNA（UTS #46）将国际化域名转换为 ASCII 形式，包括大小写映射、NFC 规范化、
+// 双向文本校验与 Punycode 编码，转换后按 RFC 1123 校验主机名，如 例子.中国 转换为 xn--fsqu00a.xn--fiqs8s
+func IDNToASCII(host string) (string, error) {
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", ErrHostnameFormat
+	}
+	if err := ValidateHostname(ascii); err != nil {
+		return "", err
+	}
+	return ascii, nil
+}
@@ -0,0 +1,322 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrEmailFormat 邮箱地址格式错误
+	ErrEmailFormat = errors.New("email address is malformed")
+	// ErrEmailLength 邮箱地址过长
+	ErrEmailLength = errors.New("email address is too long")
+	// ErrEmailDomain 邮箱地址的域名错误
+	ErrEmailDomain = errors.New("email domain is invalid")
+	// ErrUrlFormat URL 格式错误
+	ErrUrlFormat = errors.New("url is malformed")
+	// ErrUrlNotAbsolute URL 不是绝对地址
+	ErrUrlNotAbsolute = errors.New("url is not absolute")
+	// ErrUrlScheme URL 的协议不在允许的范围内
+	ErrUrlScheme = errors.New("url scheme is not allowed")
+	// ErrUrlHost URL 的主机错误
+	ErrUrlHost = errors.New("url host is invalid")
+	// ErrHostnameLength 主机名为空或过长
+	ErrHostnameLength = errors.New("hostname must be 1 to 253 characters")
+	// ErrHostnameFormat 主机名格式错误
+	ErrHostnameFormat = errors.New("hostname is malformed")
+	// ErrIPFormat IP 地址格式错误
+	ErrIPFormat = errors.New("ip address is malformed")
+	// ErrCIDRFormat CIDR 格式错误
+	ErrCIDRFormat = errors.New("cidr is malformed")
+	// ErrIPNotInCIDR IP 地址不在指定的网段内
+	ErrIPNotInCIDR = errors.New("ip address is not in the allowed cidr")
+	// ErrPort 端口错误
+	ErrPort = errors.New("port must be a number between 1 and 65535")
+	// ErrPortRange 端口范围错误
+	ErrPortRange = errors.New("port range is invalid")
+)
+
+// defaultUrlSchemes ValidateUrl 未指定协议时允许的协议
+var defaultUrlSchemes = []string{"http", "https"}
+
+// emailSpecials 邮箱地址本地部分 dot-atom 允许的特殊字符（RFC 5322 atext）
+const emailSpecials = "!#$%&'*+-/=?^_`{|}~"
+
+// ValidateEmail 按 RFC 5322 的 addr-spec 校验邮箱地址，本地部分支持 dot-atom 与带引号的形式，
+// 域名需为至少两级的主机名或 [IPv4]、[IPv6:...] 形式的地址，失败时返回原因
+func ValidateEmail(email string) error {
+	return validateEmail(email, false)
+}
+
+// ValidateEmailIDN 校验邮箱地址，允许国际化域名及 UTF-8 本地部分（RFC 6531）
+func ValidateEmailIDN(email string) error {
+	return validateEmail(email, true)
+}
+
+func validateEmail(email string, idn bool) error {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return ErrEmailFormat
+	}
+	local, domain := email[:at], email[at+1:]
+	if len(local) > 64 {
+		return ErrEmailLength
+	}
+	if !validEmailLocal(local, idn) {
+		return ErrEmailFormat
+	}
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		if !validEmailLiteral(domain[1 : len(domain)-1]) {
+			return ErrEmailDomain
+		}
+	} else {
+		var err error
+		if idn {
+			domain, err = IDNToASCII(domain)
+		} else {
+			err = ValidateHostname(domain)
+		}
+		if err != nil || strings.HasSuffix(domain, ".") || !strings.Contains(domain, ".") {
+			return ErrEmailDomain
+		}
+	}
+	if len(local)+1+len(domain) > 254 {
+		return ErrEmailLength
+	}
+	return nil
+}
+
+// validEmailLocal 校验邮箱地址的本地部分
+func validEmailLocal(local string, idn bool) bool {
+	if !utf8.ValidString(local) {
+		return false
+	}
+	if len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"' {
+		escaped := false
+		for _, r := range local[1 : len(local)-1] {
+			switch {
+			case r >= utf8.RuneSelf:
+				if !idn {
+					return false
+				}
+			case escaped:
+				if r != '\t' && (r < ' ' || r > '~') {
+					return false
+				}
+			case r == '\\':
+				escaped = true
+				continue
+			case r == '"' || r < ' ' || r > '~':
+				return false
+			}
+			escaped = false
+		}
+		return !escaped
+	}
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return false
+		}
+		for _, r := range atom {
+			switch {
+			case r >= utf8.RuneSelf:
+				if !idn {
+					return false
+				}
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			case strings.ContainsRune(emailSpecials, r):
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validEmailLiteral 校验邮箱地址中方括号内的 IP 地址
+func validEmailLiteral(literal string) bool {
+	if v6, found := strings.CutPrefix(literal, "IPv6:"); found {
+		addr, err := netip.ParseAddr(v6)
+		return err == nil && addr.Is6() && addr.Zone() == ""
+	}
+	addr, err := netip.ParseAddr(literal)
+	return err == nil && addr.Is4()
+}
+
+// ValidateUrl 校验带主机的绝对 URL，协议必须在 schemes 中（不区分大小写），未指定时只允许 http 与 https。
+// 主机可以是主机名、国际化域名或 IP 地址，端口存在时必须在 1-65535 之间
+func ValidateUrl(rawUrl string, schemes ...string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUrlFormat, err)
+	}
+	if !u.IsAbs() {
+		return ErrUrlNotAbsolute
+	}
+	if len(schemes) == 0 {
+		schemes = defaultUrlSchemes
+	}
+	allowed := false
+	for _, scheme := range schemes {
+		allowed = allowed || strings.EqualFold(scheme, u.Scheme)
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s", ErrUrlScheme, u.Scheme)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return ErrUrlHost
+	}
+	if strings.HasPrefix(u.Host, "[") {
+		if addr, err := netip.ParseAddr(host); err != nil || !addr.Is6() {
+			return fmt.Errorf("%w: %s", ErrUrlHost, host)
+		}
+	} else if _, err := netip.ParseAddr(host); err != nil {
+		if _, err := IDNToASCII(host); err != nil {
+			return fmt.Errorf("%w: %s", ErrUrlHost, host)
+		}
+	}
+	if port := u.Port(); port != "" {
+		return ValidatePort(port)
+	}
+	if strings.HasSuffix(u.Host, ":") {
+		return ErrPort
+	}
+	return nil
+}
+
+// CheckUrl check absolute url with allowed schemes, http and https by default
+func CheckUrl(rawUrl string, schemes ...string) bool {
+	return ValidateUrl(rawUrl, schemes...) == nil
+}
+
+// ValidateHostname 按 RFC 1123 校验 ASCII 主机名：总长度不超过253，每级标签1-63个字母、数字或连字符，
+// 不能以连字符开头或结尾，顶级标签不能全为数字，允许以点结尾。国际化域名请先使用 IDNToASCII 转换
+func ValidateHostname(host string) error {
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 {
+		return ErrHostnameLength
+	}
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return ErrHostnameFormat
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return ErrHostnameFormat
+			}
+		}
+	}
+	if isDigits(labels[len(labels)-1]) {
+		return ErrHostnameFormat
+	}
+	return nil
+}
+
+// CheckHostname check hostname by RFC 1123
+func CheckHostname(host string) bool {
+	return ValidateHostname(host) == nil
+}
+
+// ValidateIP 校验 IPv4 或 IPv6 地址
+func ValidateIP(ip string) error {
+	if _, err := netip.ParseAddr(ip); err != nil {
+		return fmt.Errorf("%w: %s", ErrIPFormat, ip)
+	}
+	return nil
+}
+
+// CheckIP check IPv4 or IPv6 address
+func CheckIP(ip string) bool {
+	return ValidateIP(ip) == nil
+}
+
+// CheckIPv4 check IPv4 address, such as 192.168.1.1
+func CheckIPv4(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && addr.Is4()
+}
+
+// CheckIPv6 check IPv6 address, including IPv4-mapped address such as ::ffff:192.168.1.1
+func CheckIPv6(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && addr.Is6()
+}
+
+// CheckCIDR check CIDR notation, such as 192.168.0.0/16 or 2001:db8::/32
+func CheckCIDR(cidr string) bool {
+	_, err := netip.ParsePrefix(cidr)
+	return err == nil
+}
+
+// ValidateIPInCIDR 校验 IP 地址是否属于任一网段，IPv4 映射的 IPv6 地址按 IPv4 地址匹配
+func ValidateIPInCIDR(ip string, cidrs ...string) error {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrIPFormat, ip)
+	}
+	addr = addr.Unmap()
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrCIDRFormat, cidr)
+		}
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	return ErrIPNotInCIDR
+}
+
+// CheckIPInCIDR check whether ip belongs to any of the cidrs
+func CheckIPInCIDR(ip string, cidrs ...string) bool {
+	return ValidateIPInCIDR(ip, cidrs...) == nil
+}
+
+// ValidatePort 校验端口，必须是 1-65535 之间的数字
+func ValidatePort(port string) error {
+	_, err := parsePort(port)
+	return err
+}
+
+// CheckPort check port between 1 and 65535
+func CheckPort(port string) bool {
+	return ValidatePort(port) == nil
+}
+
+// ParsePortRange 解析端口范围，如 8080 或 8000-8080，起始端口不能大于结束端口
+func ParsePortRange(portRange string) (from, to int, err error) {
+	start, end, found := strings.Cut(portRange, "-")
+	if from, err = parsePort(start); err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return from, from, nil
+	}
+	if to, err = parsePort(end); err != nil {
+		return 0, 0, err
+	}
+	if from > to {
+		return 0, 0, fmt.Errorf("%w: %s", ErrPortRange, portRange)
+	}
+	return from, to, nil
+}
+
+// parsePort 解析 1-65535 之间的端口
+func parsePort(port string) (int, error) {
+	if !isDigits(port) || len(port) > 5 {
+		return 0, ErrPort
+	}
+	p, _ := strconv.Atoi(port)
+	if p < 1 || p > 65535 {
+		return 0, ErrPort
+	}
+	return p, nil
+}
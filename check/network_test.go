@@ -0,0 +1,160 @@
+package check
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		idn   bool
+		want  error
+	}{
+		{"admin@example.com", false, nil},
+		{"first.last+tag@mail.example.com.cn", false, nil},
+		{`"john doe"@example.com`, false, nil},
+		{`"a\"b"@example.com`, false, nil},
+		{"admin@[192.168.1.1]", false, nil},
+		{"admin@[IPv6:2001:db8::1]", false, nil},
+		{"用户@例子.中国", true, nil},
+		{"admin@例子.中国", false, ErrEmailDomain},
+		{"用户@example.com", false, ErrEmailFormat},
+		{"admin", false, ErrEmailFormat},
+		{"@example.com", false, ErrEmailFormat},
+		{"a..b@example.com", false, ErrEmailFormat},
+		{".a@example.com", false, ErrEmailFormat},
+		{`"a"b"@example.com`, false, ErrEmailFormat},
+		{"admin@example", false, ErrEmailDomain},
+		{"admin@-example.com", false, ErrEmailDomain},
+		{"admin@[300.1.1.1]", false, ErrEmailDomain},
+		{"a234567890123456789012345678901234567890123456789012345678901234x@example.com", false, ErrEmailLength},
+	}
+	for _, tt := range tests {
+		validate := ValidateEmail
+		if tt.idn {
+			validate = ValidateEmailIDN
+		}
+		if got := validate(tt.email); !errors.Is(got, tt.want) {
+			t.Errorf("ValidateEmail(%s, idn=%v) = %v, want %v", tt.email, tt.idn, got, tt.want)
+		}
+	}
+}
+
+func TestIDNToASCII(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"例子.中国", "xn--fsqu00a.xn--fiqs8s"},
+		{"bücher.example", "xn--bcher-kva.example"},
+		{"Example.COM", "example.com"},
+		{"Bücher.example", "xn--bcher-kva.example"},
+		{"bu\u0308cher.example", "xn--bcher-kva.example"},
+	}
+	for _, tt := range tests {
+		if got, err := IDNToASCII(tt.host); err != nil || got != tt.want {
+			t.Errorf("IDNToASCII(%s) = %s, %v, want %s", tt.host, got, err, tt.want)
+		}
+	}
+	for _, host := range []string{"a b.com", "-例子.中国", "\u05d0a.com"} {
+		if _, err := IDNToASCII(host); !errors.Is(err, ErrHostnameFormat) {
+			t.Errorf("IDNToASCII(%s) = %v, want ErrHostnameFormat", host, err)
+		}
+	}
+}
+
+func TestValidateUrl(t *testing.T) {
+	tests := []struct {
+		url     string
+		schemes []string
+		want    error
+	}{
+		{"https://api.example.com/callback?a=1", nil, nil},
+		{"http://127.0.0.1:8080/notify", nil, nil},
+		{"http://[::1]:8080/", nil, nil},
+		{"https://例子.中国/", nil, nil},
+		{"wss://push.example.com/ws", []string{"ws", "wss"}, nil},
+		{"/callback", nil, ErrUrlNotAbsolute},
+		{"ftp://example.com/", nil, ErrUrlScheme},
+		{"https:///path", nil, ErrUrlHost},
+		{"mailto:admin@example.com", []string{"mailto"}, ErrUrlHost},
+		{"https://exa_mple.com/", nil, ErrUrlHost},
+		{"https://example.com:70000/", nil, ErrPort},
+		{"https://example.com:/", nil, ErrPort},
+		{"https://exa mple.com/", nil, ErrUrlFormat},
+	}
+	for _, tt := range tests {
+		if got := ValidateUrl(tt.url, tt.schemes...); !errors.Is(got, tt.want) {
+			t.Errorf("ValidateUrl(%s) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	tests := []struct {
+		host string
+		want error
+	}{
+		{"localhost", nil},
+		{"api-1.example.com.", nil},
+		{"", ErrHostnameLength},
+		{"a..com", ErrHostnameFormat},
+		{"-a.com", ErrHostnameFormat},
+		{"a_b.com", ErrHostnameFormat},
+		{"192.168.1.1", ErrHostnameFormat},
+		{"a123456789012345678901234567890123456789012345678901234567890123.com", ErrHostnameFormat},
+	}
+	for _, tt := range tests {
+		if got := ValidateHostname(tt.host); !errors.Is(got, tt.want) {
+			t.Errorf("ValidateHostname(%s) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestCheckIP(t *testing.T) {
+	if !CheckIPv4("192.168.1.1") || CheckIPv4("::1") || CheckIPv4("256.1.1.1") {
+		t.Error("CheckIPv4() was incorrect")
+	}
+	if !CheckIPv6("2001:db8::1") || CheckIPv6("10.0.0.1") || !CheckIP("10.0.0.1") || CheckIP("abc") {
+		t.Error("CheckIPv6() or CheckIP() was incorrect")
+	}
+	if !CheckCIDR("10.0.0.0/8") || CheckCIDR("10.0.0.0/33") || CheckCIDR("10.0.0.0") {
+		t.Error("CheckCIDR() was incorrect")
+	}
+	private := []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+	for _, ip := range []string{"10.1.2.3", "172.31.255.255", "::ffff:192.168.1.1", "fd00::1"} {
+		if err := ValidateIPInCIDR(ip, private...); err != nil {
+			t.Errorf("ValidateIPInCIDR(%s) = %v", ip, err)
+		}
+	}
+	if err := ValidateIPInCIDR("8.8.8.8", private...); !errors.Is(err, ErrIPNotInCIDR) {
+		t.Errorf("ValidateIPInCIDR(8.8.8.8) = %v", err)
+	}
+	if err := ValidateIPInCIDR("8.8.8.8", "8.8.8.8/40"); !errors.Is(err, ErrCIDRFormat) {
+		t.Errorf("ValidateIPInCIDR(invalid cidr) = %v", err)
+	}
+}
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		portRange string
+		from, to  int
+		want      error
+	}{
+		{"8080", 8080, 8080, nil},
+		{"8000-8080", 8000, 8080, nil},
+		{"1-65535", 1, 65535, nil},
+		{"0", 0, 0, ErrPort},
+		{"65536", 0, 0, ErrPort},
+		{"+80", 0, 0, ErrPort},
+		{"8080-8000", 0, 0, ErrPortRange},
+		{"8000-", 0, 0, ErrPort},
+	}
+	for _, tt := range tests {
+		from, to, err := ParsePortRange(tt.portRange)
+		if from != tt.from || to != tt.to || !errors.Is(err, tt.want) {
+			t.Errorf("ParsePortRange(%s) = %d, %d, %v", tt.portRange, from, to, err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package check
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// CharClass 密码的字符类别
+type CharClass string
+
+const (
+	ClassLower  CharClass = "lower"  // 小写字母
+	ClassUpper  CharClass = "upper"  // 大写字母
+	ClassDigit  CharClass = "digit"  // 数字
+	ClassSymbol CharClass = "symbol" // 符号及其他字符
+)
+
+// allCharClasses 所有字符类别，按报告的顺序排列
+var allCharClasses = []CharClass{ClassLower, ClassUpper, ClassDigit, ClassSymbol}
+
+// PasswordStrength 密码强度的评估结果
+type PasswordStrength struct {
+	Length  int         // 字符数
+	Score   int         // 强度分数 0-5：每包含一种字符类别加1分，不少于12个字符再加1分，少于8个字符最多1分
+	Classes []CharClass // 包含的字符类别
+	Missing []CharClass // 缺少的字符类别
+}
+
+// ScorePassword 评估密码强度，报告包含与缺少的字符类别
+func ScorePassword(password string) PasswordStrength {
+	present := make(map[CharClass]bool, len(allCharClasses))
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			present[ClassLower] = true
+		case unicode.IsUpper(r):
+			present[ClassUpper] = true
+		case unicode.IsDigit(r):
+			present[ClassDigit] = true
+		default:
+			present[ClassSymbol] = true
+		}
+	}
+	strength := PasswordStrength{Length: utf8.RuneCountInString(password)}
+	for _, class := range allCharClasses {
+		if present[class] {
+			strength.Classes = append(strength.Classes, class)
+		} else {
+			strength.Missing = append(strength.Missing, class)
+		}
+	}
+	strength.Score = len(strength.Classes)
+	if strength.Length >= 12 {
+		strength.Score++
+	}
+	if strength.Length < 8 {
+		strength.Score = min(strength.Score, 1)
+	}
+	return strength
+}
+
+// PasswordPolicy 密码策略
+type PasswordPolicy struct {
+	MinLength  int         // 最少字符数，为 0 时不限制
+	MaxLength  int         // 最多字符数，为 0 时不限制
+	Required   []CharClass // 必须包含的字符类别
+	MinClasses int         // 至少包含的字符类别数量
+}
+
+// DefaultPasswordPolicy 默认的密码策略：8-32个字符，至少包含三种字符类别
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:  8,
+	MaxLength:  32,
+	MinClasses: 3,
+}
+
+// Check 按策略校验密码，返回密码强度与是否满足策略。
+// 不满足 Required 时，结果的 Missing 只包含策略要求但缺少的字符类别
+func (p PasswordPolicy) Check(password string) (PasswordStrength, bool) {
+	strength := ScorePassword(password)
+	ok := strength.Length >= p.MinLength && (p.MaxLength <= 0 || strength.Length <= p.MaxLength) &&
+		len(strength.Classes) >= p.MinClasses
+	if len(p.Required) > 0 {
+		var missing []CharClass
+		for _, required := range p.Required {
+			for _, class := range strength.Missing {
+				if class == required {
+					missing = append(missing, class)
+				}
+			}
+		}
+		if len(missing) > 0 {
+			strength.Missing = missing
+			ok = false
+		}
+	}
+	return strength, ok
+}
@@ -0,0 +1,105 @@
+package check
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// 内置的命名规则，可通过 RegisterRule 在启动时覆盖
+const (
+	RuleMobile           = "mobile"
+	RuleUserName         = "username"
+	RulePassword         = "password"
+	RuleCreditCode       = "creditcode"
+	RulePassport         = "passport"
+	RuleHKMacaoPermit    = "hkmacaopermit"
+	RuleHomeReturnPermit = "homereturnpermit"
+	RuleLicensePlate     = "plate"
+	RuleNewEnergyPlate   = "newenergyplate"
+	RulePostalCode       = "postcode"
+	RuleLandline         = "landline"
+)
+
+var (
+	rules    = make(map[string]*regexp.Regexp) // 命名规则
+	rulesMux sync.RWMutex
+	patterns sync.Map // Check 使用的已编译正则表达式
+)
+
+func init() {
+	MustRegisterRule(RuleMobile, "^1[3-9]{1}[0-9]{9}$")
+	MustRegisterRule(RuleUserName, "^[a-zA-Z]+[a-zA-Z0-9_-]{4,17}$")
+	MustRegisterRule(RulePassword, "^[a-zA-Z0-9_-|@.]{5,18}$")
+	MustRegisterRule(RuleCreditCode, "^[1-9A-HJ-NP-RT-UW-Y][0-9A-HJ-NP-RT-UW-Y][0-9]{6}[0-9A-HJ-NP-RT-UW-Y]{10}$")
+	MustRegisterRule(RulePassport, "^([EG][0-9]{8}|E[A-HJ-NP-Z][0-9]{7}|[DSP]E[0-9]{7})$")
+	MustRegisterRule(RuleHKMacaoPermit, "^([CW][0-9]{8}|C[A-HJ-NP-Z][0-9]{7})$")
+	MustRegisterRule(RuleHomeReturnPermit, "^[HM][0-9]{8}([0-9]{2})?$")
+	MustRegisterRule(RuleLicensePlate, "^["+plateProvinces+"][A-HJ-NP-Z][A-HJ-NP-Z0-9]{4}[A-HJ-NP-Z0-9挂学警港澳]$")
+	MustRegisterRule(RuleNewEnergyPlate, "^["+plateProvinces+"][A-HJ-NP-Z]([A-HJ-K][A-HJ-NP-Z0-9][0-9]{4}|[0-9]{5}[A-HJ-K])$")
+	MustRegisterRule(RulePostalCode, "^[0-8][0-9]{5}$")
+	MustRegisterRule(RuleLandline, "^(0[1-9][0-9]-?[2-9][0-9]{7}|0[1-9][0-9]{2}-?[2-9][0-9]{6,7})(-[0-9]{1,6})?$")
+}
+
+// RegisterRule 注册命名规则，已存在的同名规则会被覆盖，如更新手机号码号段：
+//
+//	check.RegisterRule(check.RuleMobile, `^1(3\d|4[5-9]|5[0-35-9]|6[2567]|7[0-8]|8\d|9[0-35-9])\d{8}$`)
+func RegisterRule(name, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern for rule %s: %w", name, err)
+	}
+	rulesMux.Lock()
+	defer rulesMux.Unlock()
+	rules[name] = re
+	return nil
+}
+
+// MustRegisterRule 注册命名规则，正则表达式无效时 panic
+func MustRegisterRule(name, pattern string) {
+	if err := RegisterRule(name, pattern); err != nil {
+		panic(err)
+	}
+}
+
+// HasRule 判断命名规则是否存在
+func HasRule(name string) bool {
+	rulesMux.RLock()
+	defer rulesMux.RUnlock()
+	_, found := rules[name]
+	return found
+}
+
+// RuleNames 返回所有命名规则的名称
+func RuleNames() []string {
+	rulesMux.RLock()
+	defer rulesMux.RUnlock()
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckRule 使用命名规则校验字符串，规则不存在时返回 false
+func CheckRule(name, s string) bool {
+	rulesMux.RLock()
+	re, found := rules[name]
+	rulesMux.RUnlock()
+	return found && re.MatchString(s)
+}
+
+// Check 使用正则表达式校验字符串，编译后的正则表达式会被缓存，正则表达式无效时返回 false
+func Check(pattern, s string) bool {
+	if re, found := patterns.Load(pattern); found {
+		return re.(*regexp.Regexp).MatchString(s)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	actual, _ := patterns.LoadOrStore(pattern, re)
+	return actual.(*regexp.Regexp).MatchString(s)
+}
@@ -0,0 +1,89 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	if !Check("^[0-9]+$", "123") || Check("^[0-9]+$", "12a") {
+		t.Error("Check() with digits pattern was incorrect")
+	}
+	if Check("[", "[") {
+		t.Error("Check() with invalid pattern = true, want false")
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	if !HasRule(RuleMobile) || HasRule("unknown") || CheckRule("unknown", "x") {
+		t.Error("HasRule() or CheckRule() with unknown rule was incorrect")
+	}
+	if err := RegisterRule("broken", "["); err == nil {
+		t.Error("RegisterRule(invalid) expected error")
+	}
+
+	// 覆盖内置规则，如只允许指定号段的手机号码
+	if !CheckMobile("14012345678") {
+		t.Fatal("CheckMobile(14012345678) = false before override")
+	}
+	MustRegisterRule(RuleMobile, `^1(3\d|4[5-9]|5[0-35-9]|6[2567]|7[0-8]|8\d|9[0-35-9])\d{8}$`)
+	defer MustRegisterRule(RuleMobile, "^1[3-9]{1}[0-9]{9}$")
+	if CheckMobile("14012345678") || !CheckMobile("19212345678") {
+		t.Error("CheckMobile() after override was incorrect")
+	}
+
+	MustRegisterRule("order", "^ORD[0-9]{6}$")
+	if !CheckRule("order", "ORD123456") || CheckRule("order", "ORD12345") {
+		t.Error("CheckRule(order) was incorrect")
+	}
+	found := false
+	for _, name := range RuleNames() {
+		found = found || name == "order"
+	}
+	if !found {
+		t.Error("RuleNames() missing order")
+	}
+}
+
+func TestScorePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		score    int
+		missing  []CharClass
+	}{
+		{"", 0, []CharClass{ClassLower, ClassUpper, ClassDigit, ClassSymbol}},
+		{"abc123", 1, []CharClass{ClassUpper, ClassSymbol}},
+		{"abcdefgh", 1, []CharClass{ClassUpper, ClassDigit, ClassSymbol}},
+		{"abcd1234", 2, []CharClass{ClassUpper, ClassSymbol}},
+		{"Abcd1234", 3, []CharClass{ClassSymbol}},
+		{"Abcd1234!", 4, nil},
+		{"Abcd1234!xyz", 5, nil},
+		{"密码Abcd1234", 4, nil},
+	}
+	for _, tt := range tests {
+		got := ScorePassword(tt.password)
+		if got.Score != tt.score || !reflect.DeepEqual(got.Missing, tt.missing) {
+			t.Errorf("ScorePassword(%q) = %+v, want score %d missing %v", tt.password, got, tt.score, tt.missing)
+		}
+	}
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	if _, ok := DefaultPasswordPolicy.Check("Abcd1234"); !ok {
+		t.Error("DefaultPasswordPolicy.Check(Abcd1234) = false")
+	}
+	if _, ok := DefaultPasswordPolicy.Check("abcd1234"); ok {
+		t.Error("DefaultPasswordPolicy.Check(abcd1234) = true")
+	}
+	if _, ok := DefaultPasswordPolicy.Check("Ab1"); ok {
+		t.Error("DefaultPasswordPolicy.Check(Ab1) = true")
+	}
+	policy := PasswordPolicy{MinLength: 6, Required: []CharClass{ClassDigit, ClassSymbol}}
+	strength, ok := policy.Check("Abcdef1")
+	if ok || !reflect.DeepEqual(strength.Missing, []CharClass{ClassSymbol}) {
+		t.Errorf("policy.Check(Abcdef1) = %+v, %v", strength, ok)
+	}
+	if _, ok := policy.Check("abcde1!"); !ok {
+		t.Error("policy.Check(abcde1!) = false")
+	}
+}
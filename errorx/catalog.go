@@ -0,0 +1,308 @@
+package errorx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCatalog 默认的错误码目录，默认语言为 zh-CN
+var DefaultCatalog = NewCatalog("zh-CN")
+
+// Catalog 错误码目录，保存每个错误码在各语言下的消息模板。
+// 模板使用 {name} 形式的命名占位符，如 "用户 {name} 不存在"
+type Catalog struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	messages      map[int]map[string]string // 错误码 -> 语言 -> 模板
+}
+
+// NewCatalog 创建错误码目录，defaultLocale 为请求的语言都不存在时使用的语言
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		defaultLocale: normalizeLocale(defaultLocale),
+		messages:      make(map[int]map[string]string),
+	}
+}
+
+// Register 注册错误码在各语言下的消息模板，已存在的语言会被覆盖
+func (c *Catalog) Register(code int, templates map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locales, found := c.messages[code]
+	if !found {
+		locales = make(map[string]string, len(templates))
+		c.messages[code] = locales
+	}
+	for locale, template := range templates {
+		locales[normalizeLocale(locale)] = template
+	}
+}
+
+// LoadMap 从 Go map 批量注册消息模板
+func (c *Catalog) LoadMap(messages map[int]map[string]string) {
+	for code, templates := range messages {
+		c.Register(code, templates)
+	}
+}
+
+// LoadJSON 从 JSON 加载消息模板，格式为 {"404": {"zh-CN": "...", "en": "..."}}
+func (c *Catalog) LoadJSON(r io.Reader) error {
+	var raw map[string]map[string]string
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return fmt.Errorf("decode catalog: %w", err)
+	}
+	messages := make(map[int]map[string]string, len(raw))
+	for key, templates := range raw {
+		code, err := strconv.Atoi(key)
+		if err != nil {
+			return fmt.Errorf("invalid error code %q", key)
+		}
+		messages[code] = templates
+	}
+	c.LoadMap(messages)
+	return nil
+}
+
+// LoadYAML 从 YAML 子集加载消息模板：顶层为错误码，下一级缩进为语言与模板，
+// 支持 # 注释与单双引号包裹的模板，如：
+//
+//	404:
+//	  zh-CN: 用户 {name} 不存在
+//	  en: "user {name} not found"
+func (c *Catalog) LoadYAML(r io.Reader) error {
+	messages := make(map[int]map[string]string)
+	var current map[string]string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return fmt.Errorf("catalog line %d: missing ':'", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if text[0] != ' ' && text[0] != '\t' {
+			code, err := strconv.Atoi(unquote(key))
+			if err != nil || value != "" {
+				return fmt.Errorf("catalog line %d: invalid error code %q", line, key)
+			}
+			current = make(map[string]string)
+			messages[code] = current
+			continue
+		}
+		if current == nil {
+			return fmt.Errorf("catalog line %d: locale without error code", line)
+		}
+		current[unquote(key)] = unquote(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read catalog: %w", err)
+	}
+	c.LoadMap(messages)
+	return nil
+}
+
+// LoadFile 按扩展名从 .json、.yaml 或 .yml 文件加载消息模板
+func (c *Catalog) LoadFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return c.LoadJSON(bytes.NewReader(data))
+	case ".yaml", ".yml":
+		return c.LoadYAML(bytes.NewReader(data))
+	default:
+		return fmt.Errorf("unsupported catalog file %s", filename)
+	}
+}
+
+// Lookup 按错误码与语言查找模板，locale 可以是 Accept-Language 请求头。
+// 依次尝试各语言的完整标签、主语言（如 zh-TW 回退到 zh）、同主语言的其他地区，最后使用默认语言
+func (c *Catalog) Lookup(code int, locale string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	templates, found := c.messages[code]
+	if !found {
+		return "", false
+	}
+	for _, tag := range ParseAcceptLanguage(locale) {
+		if template, found := templates[tag]; found {
+			return template, true
+		}
+		base, _, _ := strings.Cut(tag, "-")
+		if template, found := templates[base]; found {
+			return template, true
+		}
+		if template, found := lookupRegion(templates, base); found {
+			return template, true
+		}
+	}
+	template, found := templates[c.defaultLocale]
+	return template, found
+}
+
+// Message 按错误码与语言渲染消息，错误码未定义时返回 "error {code}"
+func (c *Catalog) Message(code int, locale string, params map[string]any) string {
+	template, found := c.Lookup(code, locale)
+	if !found {
+		return "error " + strconv.Itoa(code)
+	}
+	return Render(template, params)
+}
+
+// New 按错误码与语言创建错误，params 会作为错误的 Details
+func (c *Catalog) New(code int, locale string, params map[string]any) *Error {
+	e := New(code, c.Message(code, locale, params))
+	if len(params) > 0 {
+		e = e.WithDetails(params)
+	}
+	return e
+}
+
+// Localize 使用错误的 Details 作为参数，将消息翻译为指定语言，错误码未定义时返回原错误
+func (c *Catalog) Localize(e *Error, locale string) *Error {
+	template, found := c.Lookup(e.Code, locale)
+	if !found {
+		return e
+	}
+	return e.MessageOf(Render(template, e.Details))
+}
+
+// Check 检查错误码是否都已定义默认语言的模板，以及已注册的错误码是否都有默认语言的模板，
+// 用于启动时确认代码中使用的错误码与目录一致
+func (c *Catalog) Check(codes ...int) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var errs []error
+	for _, code := range codes {
+		if _, found := c.messages[code]; !found {
+			errs = append(errs, fmt.Errorf("error code %d is not defined", code))
+		}
+	}
+	defined := make([]int, 0, len(c.messages))
+	for code := range c.messages {
+		defined = append(defined, code)
+	}
+	sort.Ints(defined)
+	for _, code := range defined {
+		if _, found := c.messages[code][c.defaultLocale]; !found {
+			errs = append(errs, fmt.Errorf("error code %d has no %s message", code, c.defaultLocale))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Codes 返回已定义的错误码
+func (c *Catalog) Codes() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	codes := make([]int, 0, len(c.messages))
+	for code := range c.messages {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+// Render 使用命名参数渲染模板，如 Render("用户 {name} 不存在", map[string]any{"name": "tom"})，
+// 没有对应参数的占位符保持原样
+func Render(template string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		sb.WriteString(template[:start])
+		if value, found := params[template[start+1:end]]; found {
+			sb.WriteString(fmt.Sprint(value))
+		} else {
+			sb.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	sb.WriteString(template)
+	return sb.String()
+}
+
+// ParseAcceptLanguage 解析 Accept-Language 请求头，按权重从高到低返回规范化的语言标签，
+// 如 "en-US,en;q=0.9,zh-CN;q=0.8" 返回 [en-us en zh-cn]，忽略 * 与权重为 0 的语言
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = normalizeLocale(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+	return result
+}
+
+// lookupRegion 查找同一主语言的其他地区的模板，有多个时取标签最小的一个以保证结果稳定
+func lookupRegion(templates map[string]string, base string) (string, bool) {
+	match := ""
+	for tag := range templates {
+		if strings.HasPrefix(tag, base+"-") && (match == "" || tag < match) {
+			match = tag
+		}
+	}
+	template, found := templates[match]
+	return template, found && match != ""
+}
+
+// normalizeLocale 规范化语言标签：去除空白、转为小写并使用 - 分隔，如 zh_CN 转为 zh-cn
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// unquote 去除单引号或双引号
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
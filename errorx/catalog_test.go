@@ -0,0 +1,115 @@
+package errorx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const catalogYAML = `
+# 用户相关错误
+40401:
+  zh-CN: 用户 {name} 不存在
+  en: "user {name} not found"
+40001:
+  zh-CN: '参数 {field} 错误: {reason}'
+  en-US: invalid parameter {field}
+`
+
+func newTestCatalog(t *testing.T) *Catalog {
+	c := NewCatalog("zh-CN")
+	if err := c.LoadYAML(strings.NewReader(catalogYAML)); err != nil {
+		t.Fatalf("LoadYAML() error = %v", err)
+	}
+	return c
+}
+
+func TestCatalogLookup(t *testing.T) {
+	c := newTestCatalog(t)
+	params := map[string]any{"name": "tom", "field": "age"}
+	tests := []struct {
+		code   int
+		locale string
+		want   string
+	}{
+		{40401, "zh-CN", "用户 tom 不存在"},
+		{40401, "zh_cn", "用户 tom 不存在"},
+		{40401, "en-US,en;q=0.9", "user tom not found"},
+		{40401, "fr;q=0.9, en;q=0.8", "user tom not found"},
+		{40401, "ja", "用户 tom 不存在"},
+		{40401, "", "用户 tom 不存在"},
+		{40001, "en", "invalid parameter age"},
+		{40001, "zh-TW", "参数 age 错误: {reason}"},
+		{50000, "en", "error 50000"},
+	}
+	for _, tt := range tests {
+		if got := c.Message(tt.code, tt.locale, params); got != tt.want {
+			t.Errorf("Message(%d, %s) = %s, want %s", tt.code, tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogLocalize(t *testing.T) {
+	c := newTestCatalog(t)
+	err := c.New(40401, "zh-CN", map[string]any{"name": "tom"})
+	if err.Message != "用户 tom 不存在" || err.Details["name"] != "tom" {
+		t.Errorf("New() = %+v", err)
+	}
+	if got := c.Localize(err, "en").Message; got != "user tom not found" {
+		t.Errorf("Localize() = %s", got)
+	}
+	unknown := New(50000, "server busy")
+	if c.Localize(unknown, "en") != unknown {
+		t.Errorf("Localize() of unknown code should return the error itself")
+	}
+}
+
+func TestCatalogLoad(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "errors.json")
+	if err := os.WriteFile(filename, []byte(`{"40301": {"zh-CN": "无权限", "en": "forbidden"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := newTestCatalog(t)
+	c.LoadMap(map[int]map[string]string{50001: {"zh-CN": "服务繁忙"}})
+	if err := c.LoadFile(filename); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if got := c.Codes(); !reflect.DeepEqual(got, []int{40001, 40301, 40401, 50001}) {
+		t.Errorf("Codes() = %v", got)
+	}
+	if err := c.LoadFile(filepath.Join(dir, "errors.toml")); err == nil {
+		t.Errorf("LoadFile(toml) expected error")
+	}
+	if err := c.LoadYAML(strings.NewReader("  en: orphan")); err == nil {
+		t.Errorf("LoadYAML(orphan locale) expected error")
+	}
+	if err := c.LoadJSON(strings.NewReader(`{"abc": {"en": "x"}}`)); err == nil {
+		t.Errorf("LoadJSON(invalid code) expected error")
+	}
+}
+
+func TestCatalogCheck(t *testing.T) {
+	c := newTestCatalog(t)
+	if err := c.Check(40401, 40001); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	c.Register(40901, map[string]string{"en": "conflict"})
+	err := c.Check(40401, 50002)
+	if err == nil || !strings.Contains(err.Error(), "error code 50002 is not defined") ||
+		!strings.Contains(err.Error(), "error code 40901 has no zh-cn message") {
+		t.Errorf("Check() error = %v", err)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("zh-CN;q=0.8, en-US, *;q=0.5, fr;q=0, en;q=0.9")
+	if !reflect.DeepEqual(got, []string{"en-us", "en", "zh-cn"}) {
+		t.Errorf("ParseAcceptLanguage() = %v", got)
+	}
+	if got := Render("{a}-{b}-{", map[string]any{"a": 1}); got != "1-{b}-{" {
+		t.Errorf("Render() = %s", got)
+	}
+}
@@ -1,13 +1,33 @@
 package errorx
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"runtime"
+	"strings"
+	"sync/atomic"
 )
 
+// stackDepth 捕获的最大调用栈深度
+const stackDepth = 32
+
+// captureStack 是否在 Wrap 时自动捕获调用栈
+var captureStack atomic.Bool
+
+// EnableStack 设置 Wrap 与 Wrapf 是否自动捕获调用栈，默认不捕获
+func EnableStack(enable bool) {
+	captureStack.Store(enable)
+}
+
 // Error is a trivial implementation of error.
 type Error struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
+	TraceId string         `json:"traceId,omitempty"`
+	cause   error
+	stack   []uintptr
 }
 
 // New returns an error that formats as the given text.
@@ -28,23 +48,160 @@ func NewParams(code int, message string, params ...any) *Error {
 	}
 }
 
-// Error return error message
+// Wrap returns an error with the given code and message that wraps err as its cause.
+// The result is never nil, a nil err simply has no cause.
+func Wrap(err error, code int, message string) *Error {
+	e := &Error{
+		Code:    code,
+		Message: message,
+		cause:   err,
+	}
+	if captureStack.Load() {
+		e.stack = callers(3)
+	}
+	return e
+}
+
+// Wrapf returns an error like Wrap with the message formatted by fmt.Sprintf.
+func Wrapf(err error, code int, message string, params ...any) *Error {
+	e := &Error{
+		Code:    code,
+		Message: fmt.Sprintf(message, params...),
+		cause:   err,
+	}
+	if captureStack.Load() {
+		e.stack = callers(3)
+	}
+	return e
+}
+
+// CodeOf return the code of the first *Error in err's chain, or 0 if there is none
+func CodeOf(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return 0
+}
+
+// Error return the public error message only, the cause is not included so that
+// it is safe to return to clients. Use Unwrap, Cause or Verbose to get the cause
 func (e *Error) Error() string {
 	return e.Message
 }
 
+// Verbose return error message followed by the cause if any, for logging.
+// fmt's %+v can't be customized because Format is already used to format the message
+func (e *Error) Verbose() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap return the cause
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an *Error with the same code, so that
+// errors.Is(err, ErrNotFound) matches any error carrying the code of ErrNotFound
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t != nil && t.Code == e.Code
+}
+
+// Cause return the root cause by following Unwrap, or nil if there is no cause
+func (e *Error) Cause() error {
+	var cause error
+	for err := e.cause; err != nil; err = errors.Unwrap(err) {
+		cause = err
+	}
+	return cause
+}
+
 // Format return a formatted and new error object
 func (e *Error) Format(params ...any) *Error {
-	return &Error{
-		Code:    e.Code,
-		Message: fmt.Sprintf(e.Message, params...),
-	}
+	c := e.clone()
+	c.Message = fmt.Sprintf(e.Message, params...)
+	return c
 }
 
 // MessageOf return a message and new error object
 func (e *Error) MessageOf(message string) *Error {
-	return &Error{
-		Code:    e.Code,
-		Message: message,
+	c := e.clone()
+	c.Message = message
+	return c
+}
+
+// WithCause return a new error object wrapping err as its cause
+func (e *Error) WithCause(err error) *Error {
+	c := e.clone()
+	c.cause = err
+	return c
+}
+
+// WithDetail return a new error object with the key/value detail added
+func (e *Error) WithDetail(key string, value any) *Error {
+	c := e.clone()
+	if c.Details == nil {
+		c.Details = make(map[string]any, 1)
+	}
+	c.Details[key] = value
+	return c
+}
+
+// WithDetails return a new error object with all the details added
+func (e *Error) WithDetails(details map[string]any) *Error {
+	c := e.clone()
+	if c.Details == nil {
+		c.Details = make(map[string]any, len(details))
 	}
+	maps.Copy(c.Details, details)
+	return c
+}
+
+// WithTraceId return a new error object with the trace id of the request
+func (e *Error) WithTraceId(traceId string) *Error {
+	c := e.clone()
+	c.TraceId = traceId
+	return c
+}
+
+// WithStack return a new error object with the stack trace of the caller
+func (e *Error) WithStack() *Error {
+	c := e.clone()
+	c.stack = callers(3)
+	return c
+}
+
+// StackTrace return the captured stack trace, one "function\n\tfile:line" per frame, or "" if not captured
+func (e *Error) StackTrace() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// clone 复制错误对象，Details 会被复制以免修改原对象
+func (e *Error) clone() *Error {
+	c := *e
+	c.Details = maps.Clone(e.Details)
+	return &c
+}
+
+// callers 捕获调用栈，skip 为需要跳过的栈帧数
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, stackDepth)
+	n := runtime.Callers(skip, pcs)
+	return pcs[:n]
 }
@@ -1,8 +1,12 @@
 package errorx
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/minlib/go-util/jsonx"
+	"io"
+	"strings"
 	"testing"
 )
 
@@ -29,3 +33,59 @@ func TestNewParams(t *testing.T) {
 func TestFormat(t *testing.T) {
 	fmt.Println(New(501, "Hello %s").Format("Zhang San"))
 }
+
+func TestWrap(t *testing.T) {
+	ErrNotFound := New(404, "not found")
+	cause := sql.ErrNoRows
+	err := Wrap(cause, 404, "user not found")
+	if !errors.Is(err, sql.ErrNoRows) || !errors.Is(err, ErrNotFound) || errors.Is(err, New(500, "server busy")) {
+		t.Errorf("errors.Is() on wrapped error was incorrect")
+	}
+	// Error 只返回公开的消息，不暴露原因
+	if err.Error() != "user not found" || fmt.Sprintf("%v", err) != "user not found" || err.Cause() != cause {
+		t.Errorf("Error() = %s, Cause() = %v", err.Error(), err.Cause())
+	}
+	if err.Verbose() != "user not found: sql: no rows in result set" {
+		t.Errorf("Verbose() = %s", err.Verbose())
+	}
+	var wrapped error = fmt.Errorf("query user: %w", err)
+	var e *Error
+	if !errors.As(wrapped, &e) || e.Code != 404 || CodeOf(wrapped) != 404 || CodeOf(cause) != 0 {
+		t.Errorf("errors.As() or CodeOf() on wrapped error was incorrect")
+	}
+	formatted := New(500, "query %s failed").WithCause(cause).Format("user")
+	if formatted.Message != "query user failed" || !errors.Is(formatted, cause) {
+		t.Errorf("Format() dropped the cause: %v", formatted)
+	}
+	if Wrap(nil, 500, "server busy").Unwrap() != nil {
+		t.Errorf("Wrap(nil) has a cause")
+	}
+}
+
+func TestWithDetail(t *testing.T) {
+	base := New(400, "invalid parameter")
+	err := base.WithDetail("field", "name").WithDetails(map[string]any{"rule": "required"})
+	if base.Details != nil || len(err.Details) != 2 || err.Details["field"] != "name" {
+		t.Errorf("WithDetail() = %v, base = %v", err.Details, base.Details)
+	}
+	if s := jsonx.MarshalString(err); s != `{"code":400,"message":"invalid parameter","details":{"field":"name","rule":"required"}}` {
+		t.Errorf("MarshalString() = %s", s)
+	}
+	if s := jsonx.MarshalString(base); s != `{"code":400,"message":"invalid parameter"}` {
+		t.Errorf("MarshalString() = %s", s)
+	}
+}
+
+func TestStack(t *testing.T) {
+	if New(500, "server busy").StackTrace() != "" || Wrap(io.EOF, 500, "read").StackTrace() != "" {
+		t.Errorf("StackTrace() captured without request")
+	}
+	if trace := New(500, "server busy").WithStack().StackTrace(); !strings.Contains(trace, "errorx.TestStack") {
+		t.Errorf("WithStack().StackTrace() = %s", trace)
+	}
+	EnableStack(true)
+	defer EnableStack(false)
+	if trace := Wrap(io.EOF, 500, "read").StackTrace(); !strings.HasPrefix(trace, "github.com/minlib/go-util/errorx.TestStack") {
+		t.Errorf("Wrap().StackTrace() = %s", trace)
+	}
+}
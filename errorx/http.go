@@ -0,0 +1,208 @@
+package errorx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+)
+
+// 转换非 errorx 错误时使用的错误码
+const (
+	CodeInternal = http.StatusInternalServerError // 未知错误
+	CodeTimeout  = http.StatusGatewayTimeout      // 请求超时
+	CodeCanceled = 499                            // 客户端取消了请求
+)
+
+// ErrorConverter 可以转为 *Error 的错误，如 validate.Errors
+type ErrorConverter interface {
+	AsError() *Error
+}
+
+// DefaultResponder 默认的错误响应器
+var DefaultResponder = NewResponder()
+
+// traceHeaders 默认读取链路追踪 ID 的请求头
+var traceHeaders = []string{"X-Trace-Id", "X-Request-Id"}
+
+// Responder 将错误转为统一的 JSON 响应 {code,message,details,traceId}
+type Responder struct {
+	mu       sync.RWMutex
+	statuses map[int]int                // 错误码 -> HTTP 状态码
+	catalog  *Catalog                   // 按 Accept-Language 翻译消息的错误码目录
+	traceId  func(*http.Request) string // 获取请求的链路追踪 ID
+}
+
+// ResponderOption Responder 的选项
+type ResponderOption func(*Responder)
+
+// WithStatus 指定错误码对应的 HTTP 状态码
+func WithStatus(code, status int) ResponderOption {
+	return func(r *Responder) {
+		r.statuses[code] = status
+	}
+}
+
+// WithStatuses 批量指定错误码对应的 HTTP 状态码
+func WithStatuses(statuses map[int]int) ResponderOption {
+	return func(r *Responder) {
+		maps.Copy(r.statuses, statuses)
+	}
+}
+
+// WithCatalog 按请求的 Accept-Language 使用错误码目录翻译消息
+func WithCatalog(catalog *Catalog) ResponderOption {
+	return func(r *Responder) {
+		r.catalog = catalog
+	}
+}
+
+// WithTraceIdFunc 指定获取请求链路追踪 ID 的函数，默认读取 X-Trace-Id 或 X-Request-Id 请求头
+func WithTraceIdFunc(fn func(*http.Request) string) ResponderOption {
+	return func(r *Responder) {
+		r.traceId = fn
+	}
+}
+
+// NewResponder 创建错误响应器
+func NewResponder(opts ...ResponderOption) *Responder {
+	r := &Responder{
+		statuses: make(map[int]int),
+		traceId:  headerTraceId,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// SetStatus 设置错误码对应的 HTTP 状态码
+func (r *Responder) SetStatus(code, status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses[code] = status
+}
+
+// Status 返回错误码对应的 HTTP 状态码。未配置时，400-599 的错误码直接作为状态码，
+// 四位与五位错误码取前三位（如 4001 与 40401 分别对应 400 与 404），结果不在 400-599 时对应 500
+func (r *Responder) Status(code int) int {
+	r.mu.RLock()
+	status, found := r.statuses[code]
+	r.mu.RUnlock()
+	if found {
+		return status
+	}
+	return defaultStatus(code)
+}
+
+// Convert 将任意错误转为 *Error：错误链中最外层的 *Error 或 ErrorConverter 按原样转换，
+// 超时与取消分别转为 CodeTimeout 与 CodeCanceled，其他错误转为 CodeInternal 并保留为原因，不向客户端暴露原始信息
+func (r *Responder) Convert(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if e := outermost(err); e != nil {
+		return e
+	}
+	var e *Error
+	var converter ErrorConverter
+	switch {
+	case errors.As(err, &e):
+		return e
+	case errors.As(err, &converter) && converter.AsError() != nil:
+		return converter.AsError()
+	case errors.Is(err, context.DeadlineExceeded):
+		return Wrap(err, CodeTimeout, "request timeout")
+	case errors.Is(err, context.Canceled):
+		return Wrap(err, CodeCanceled, "request canceled")
+	default:
+		return Wrap(err, CodeInternal, "internal server error")
+	}
+}
+
+// Write 将错误写为 JSON 响应，err 为 nil 时不写入任何内容
+func (r *Responder) Write(w http.ResponseWriter, req *http.Request, err error) {
+	e := r.Convert(err)
+	if e == nil {
+		return
+	}
+	if r.catalog != nil {
+		e = r.catalog.Localize(e, req.Header.Get("Accept-Language"))
+	}
+	if e.TraceId == "" && r.traceId != nil {
+		e = e.WithTraceId(r.traceId(req))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(r.Status(e.Code))
+	_ = json.NewEncoder(w).Encode(e)
+}
+
+// Handler 将返回错误的处理函数转为 http.Handler，返回的错误使用 Write 写为 JSON 响应
+func (r *Responder) Handler(fn func(http.ResponseWriter, *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.Write(w, req, fn(w, req))
+	})
+}
+
+// WriteError 使用 DefaultResponder 将错误写为 JSON 响应
+func WriteError(w http.ResponseWriter, req *http.Request, err error) {
+	DefaultResponder.Write(w, req, err)
+}
+
+// Decode 解析 {code,message,details,traceId} 格式的错误响应，code 缺失或为0时不视为该格式并返回错误，
+// 避免 {"message":"Not Found"} 等第三方响应被解析为没有错误码的错误
+func Decode(data []byte) (*Error, error) {
+	var e Error
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("decode error response: %w", err)
+	}
+	if e.Code == 0 {
+		return nil, errors.New("decode error response: missing code")
+	}
+	return &e, nil
+}
+
+// outermost 沿单一的 Unwrap 链查找最外层的 *Error 或 ErrorConverter，
+// 使 MultiError 等聚合错误整体转换，而不是转换为其中的某个成员
+func outermost(err error) *Error {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch v := err.(type) {
+		case *Error:
+			return v
+		case ErrorConverter:
+			if e := v.AsError(); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// defaultStatus 未配置时错误码对应的 HTTP 状态码，只接受 400-599 的错误状态码，
+// 否则错误会以 1xx、2xx 或 3xx 的状态码返回给客户端
+func defaultStatus(code int) int {
+	status := code
+	switch {
+	case code >= 1000 && code <= 5999:
+		status = code / 10
+	case code >= 10000 && code <= 59999:
+		status = code / 100
+	}
+	if status >= http.StatusBadRequest && status <= 599 {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// headerTraceId 从请求头读取链路追踪 ID
+func headerTraceId(req *http.Request) string {
+	for _, header := range traceHeaders {
+		if traceId := req.Header.Get(header); traceId != "" {
+			return traceId
+		}
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package errorx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type converterError struct{}
+
+func (converterError) Error() string { return "name is required" }
+func (converterError) AsError() *Error {
+	return New(40001, "name is required").WithDetail("field", "name")
+}
+
+func TestResponderWrite(t *testing.T) {
+	catalog := NewCatalog("zh-CN")
+	catalog.Register(40401, map[string]string{"zh-CN": "用户 {name} 不存在", "en": "user {name} not found"})
+	r := NewResponder(WithCatalog(catalog), WithStatus(40900, http.StatusConflict))
+	tests := []struct {
+		err      error
+		language string
+		status   int
+		code     int
+		message  string
+	}{
+		{New(40401, "user not found").WithDetail("name", "tom"), "en", 404, 40401, "user tom not found"},
+		{fmt.Errorf("query: %w", New(40401, "").WithDetail("name", "tom")), "zh-CN", 404, 40401, "用户 tom 不存在"},
+		{New(40900, "conflict"), "", 409, 40900, "conflict"},
+		{New(4001, "bad request"), "", 400, 4001, "bad request"},
+		{New(1, "unknown"), "", 500, 1, "unknown"},
+		{converterError{}, "", 400, 40001, "name is required"},
+		{context.DeadlineExceeded, "", 504, CodeTimeout, "request timeout"},
+		{fmt.Errorf("call: %w", context.Canceled), "", 499, CodeCanceled, "request canceled"},
+		{errors.New("dial tcp: connection refused"), "", 500, CodeInternal, "internal server error"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		req.Header.Set("Accept-Language", tt.language)
+		req.Header.Set("X-Request-Id", "req-1")
+		w := httptest.NewRecorder()
+		r.Write(w, req, tt.err)
+		var body Error
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", w.Body.String(), err)
+		}
+		if w.Code != tt.status || body.Code != tt.code || body.Message != tt.message || body.TraceId != "req-1" {
+			t.Errorf("Write(%v) = %d %s", tt.err, w.Code, w.Body.String())
+		}
+		if w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+			t.Errorf("Content-Type = %s", w.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func TestDefaultStatus(t *testing.T) {
+	tests := []struct {
+		code   int
+		status int
+	}{
+		{400, 400},
+		{503, 503},
+		{4001, 400},
+		{40401, 404},
+		{50301, 503},
+		{0, 500},
+		{100, 500},
+		{200, 500},
+		{302, 500},
+		{600, 500},
+		{1001, 500},
+		{2001, 500},
+		{10001, 500},
+		{30201, 500},
+		{60001, 500},
+	}
+	r := NewResponder(WithStatus(2001, http.StatusConflict))
+	for _, tt := range tests {
+		if got := defaultStatus(tt.code); got != tt.status {
+			t.Errorf("defaultStatus(%d) = %d, want %d", tt.code, got, tt.status)
+		}
+	}
+	if got := r.Status(2001); got != http.StatusConflict {
+		t.Errorf("Status(2001) = %d, want configured %d", got, http.StatusConflict)
+	}
+
+	// 通过真实的 HTTP 服务确认客户端收到的是错误状态码
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		WriteError(w, req, New(10001, "invalid token"))
+	}))
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("WriteError(10001) status = %d, want 500", resp.StatusCode)
+	}
+}
+
+func TestResponderHandler(t *testing.T) {
+	r := NewResponder(WithTraceIdFunc(func(*http.Request) string { return "trace-1" }))
+	handler := r.Handler(func(w http.ResponseWriter, req *http.Request) error {
+		if req.URL.Query().Get("fail") != "" {
+			return New(40301, "forbidden").WithDetail("role", "guest")
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?fail=1", nil))
+	if w.Code != http.StatusForbidden || w.Body.String() != `{"code":40301,"message":"forbidden","details":{"role":"guest"},"traceId":"trace-1"}`+"\n" {
+		t.Errorf("Handler() = %d %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+		t.Errorf("Handler() = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDecode(t *testing.T) {
+	e, err := Decode([]byte(`{"code":40401,"message":"user not found","details":{"id":1},"traceId":"t1"}`))
+	if err != nil || e.Code != 40401 || e.Message != "user not found" || e.TraceId != "t1" || e.Details["id"] != float64(1) {
+		t.Errorf("Decode() = %+v, %v", e, err)
+	}
+	if _, err := Decode([]byte(`{"message":"Not Found"}`)); err == nil {
+		t.Errorf("Decode(message without code) expected error")
+	}
+	if _, err := Decode([]byte(`{"status":"ok"}`)); err == nil {
+		t.Errorf("Decode(non envelope) expected error")
+	}
+	if _, err := Decode([]byte(`<html>`)); err == nil {
+		t.Errorf("Decode(html) expected error")
+	}
+}
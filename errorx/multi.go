@@ -0,0 +1,256 @@
+package errorx
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NoIndex 错误没有对应的下标
+const NoIndex = -1
+
+// Entry 聚合错误中的一个错误及其上下文
+type Entry struct {
+	Index int    // 下标，如批量导入的行号，没有时为 NoIndex
+	Field string // 字段，没有时为空
+	Err   error  // 错误
+}
+
+// Error 返回带下标与字段前缀的错误信息，如 "[3] mobile: mobile is invalid"
+func (e Entry) Error() string {
+	var sb strings.Builder
+	if e.Index != NoIndex {
+		sb.WriteString("[" + strconv.Itoa(e.Index) + "] ")
+	}
+	if e.Field != "" {
+		sb.WriteString(e.Field + ": ")
+	}
+	sb.WriteString(e.Err.Error())
+	return sb.String()
+}
+
+// Unwrap 返回原始错误
+func (e Entry) Unwrap() error {
+	return e.Err
+}
+
+// entryBody 聚合错误在 JSON 响应中的每一项
+type entryBody struct {
+	Index   *int   `json:"index,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// MultiError 聚合多个错误，支持 errors.Is 与 errors.As 匹配任一成员。非并发安全，并发收集请使用 Collector
+type MultiError struct {
+	entries []Entry
+	limit   int
+	dropped int
+	code    int
+	message string
+}
+
+// MultiOption MultiError 的选项
+type MultiOption func(*MultiError)
+
+// WithLimit 最多保存的错误数量，超出的错误只计数，不大于 0 时不限制
+func WithLimit(limit int) MultiOption {
+	return func(m *MultiError) {
+		m.limit = limit
+	}
+}
+
+// WithEnvelope 转为 *Error 时使用的错误码与消息，默认为 400 与 "multiple errors occurred"
+func WithEnvelope(code int, message string) MultiOption {
+	return func(m *MultiError) {
+		m.code = code
+		m.message = message
+	}
+}
+
+// NewMulti 创建聚合错误
+func NewMulti(opts ...MultiOption) *MultiError {
+	m := &MultiError{
+		code:    http.StatusBadRequest,
+		message: "multiple errors occurred",
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Add 添加错误，err 为 nil 时忽略
+func (m *MultiError) Add(err error) {
+	m.AddAt(NoIndex, "", err)
+}
+
+// AddIndex 添加带下标的错误
+func (m *MultiError) AddIndex(index int, err error) {
+	m.AddAt(index, "", err)
+}
+
+// AddField 添加带字段的错误
+func (m *MultiError) AddField(field string, err error) {
+	m.AddAt(NoIndex, field, err)
+}
+
+// AddAt 添加带下标与字段的错误，err 为 nil 时忽略，达到数量上限后只计数
+func (m *MultiError) AddAt(index int, field string, err error) {
+	if err == nil {
+		return
+	}
+	if m.limit > 0 && len(m.entries) >= m.limit {
+		m.dropped++
+		return
+	}
+	m.entries = append(m.entries, Entry{Index: index, Field: field, Err: err})
+}
+
+// Len 返回保存的错误数量
+func (m *MultiError) Len() int {
+	return len(m.entries)
+}
+
+// Dropped 返回超出数量上限而未保存的错误数量
+func (m *MultiError) Dropped() int {
+	return m.dropped
+}
+
+// Full 判断是否已达到数量上限，可用于提前结束批量处理
+func (m *MultiError) Full() bool {
+	return m.limit > 0 && len(m.entries) >= m.limit
+}
+
+// Entries 返回保存的错误
+func (m *MultiError) Entries() []Entry {
+	return append([]Entry(nil), m.entries...)
+}
+
+// ErrorOrNil 没有错误时返回 nil，否则返回自身，避免返回值为非 nil 的空接口
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.entries) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error 返回以分号分隔的所有错误信息
+func (m *MultiError) Error() string {
+	messages := make([]string, 0, len(m.entries)+1)
+	for _, entry := range m.entries {
+		messages = append(messages, entry.Error())
+	}
+	if m.dropped > 0 {
+		messages = append(messages, "and "+strconv.Itoa(m.dropped)+" more errors")
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap 返回所有成员，供 errors.Is 与 errors.As 匹配
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.entries))
+	for i, entry := range m.entries {
+		errs[i] = entry
+	}
+	return errs
+}
+
+// AsError 转为 *Error，所有成员保存在 Details 的 errors 中，成员为 *Error 时使用其错误码与消息，
+// 超出上限的数量保存在 Details 的 dropped 中
+func (m *MultiError) AsError() *Error {
+	if len(m.entries) == 0 {
+		return nil
+	}
+	bodies := make([]entryBody, len(m.entries))
+	for i, entry := range m.entries {
+		body := entryBody{Field: entry.Field, Message: entry.Err.Error()}
+		if entry.Index != NoIndex {
+			body.Index = &entry.Index
+		}
+		if e, ok := entry.Err.(*Error); ok {
+			body.Code, body.Message = e.Code, e.Message
+		} else {
+			body.Code = CodeOf(entry.Err)
+		}
+		bodies[i] = body
+	}
+	e := New(m.code, m.message).WithCause(m).WithDetail("errors", bodies)
+	if m.dropped > 0 {
+		e = e.WithDetail("dropped", m.dropped)
+	}
+	return e
+}
+
+// MarshalJSON 按 {code,message,details} 格式输出
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.AsError())
+}
+
+// Collector 并发安全的错误收集器，可在多个 goroutine 中添加错误
+type Collector struct {
+	mu    sync.Mutex
+	wg    sync.WaitGroup
+	multi *MultiError
+}
+
+// NewCollector 创建错误收集器
+func NewCollector(opts ...MultiOption) *Collector {
+	return &Collector{multi: NewMulti(opts...)}
+}
+
+// Add 添加错误，err 为 nil 时忽略
+func (c *Collector) Add(err error) {
+	c.AddAt(NoIndex, "", err)
+}
+
+// AddIndex 添加带下标的错误
+func (c *Collector) AddIndex(index int, err error) {
+	c.AddAt(index, "", err)
+}
+
+// AddField 添加带字段的错误
+func (c *Collector) AddField(field string, err error) {
+	c.AddAt(NoIndex, field, err)
+}
+
+// AddAt 添加带下标与字段的错误
+func (c *Collector) AddAt(index int, field string, err error) {
+	if err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.multi.AddAt(index, field, err)
+}
+
+// Go 在新的 goroutine 中执行 fn，返回的错误以 index 为下标收集
+func (c *Collector) Go(index int, fn func() error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.AddIndex(index, fn())
+	}()
+}
+
+// Wait 等待所有 Go 启动的函数结束，返回按下标排序的聚合错误，没有错误时返回 nil
+func (c *Collector) Wait() error {
+	c.wg.Wait()
+	return c.Err()
+}
+
+// Err 返回当前已收集错误的快照，按下标排序，没有错误时返回 nil
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := *c.multi
+	snapshot.entries = c.multi.Entries()
+	sort.SliceStable(snapshot.entries, func(i, j int) bool {
+		return snapshot.entries[i].Index < snapshot.entries[j].Index
+	})
+	return snapshot.ErrorOrNil()
+}
@@ -0,0 +1,95 @@
+package errorx
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	ErrMobile := New(40002, "mobile is invalid")
+	m := NewMulti(WithLimit(3))
+	if m.ErrorOrNil() != nil {
+		t.Errorf("ErrorOrNil() of empty MultiError should be nil")
+	}
+	m.AddAt(1, "mobile", ErrMobile)
+	m.AddIndex(2, fmt.Errorf("insert row: %w", sql.ErrNoRows))
+	m.Add(nil)
+	m.AddField("name", io.EOF)
+	m.Add(io.ErrUnexpectedEOF)
+	m.Add(io.ErrShortWrite)
+	if m.Len() != 3 || m.Dropped() != 2 || !m.Full() {
+		t.Errorf("Len() = %d, Dropped() = %d", m.Len(), m.Dropped())
+	}
+	err := m.ErrorOrNil()
+	want := "[1] mobile: mobile is invalid; [2] insert row: sql: no rows in result set; name: EOF; and 2 more errors"
+	if err.Error() != want {
+		t.Errorf("Error() = %s", err.Error())
+	}
+	var e *Error
+	var entry Entry
+	if !errors.Is(err, sql.ErrNoRows) || !errors.Is(err, New(40002, "")) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		!errors.As(err, &e) || e.Code != 40002 || !errors.As(err, &entry) || entry.Field != "mobile" {
+		t.Errorf("errors.Is() or errors.As() across members was incorrect")
+	}
+	data, _ := json.Marshal(m)
+	wantJSON := `{"code":400,"message":"multiple errors occurred","details":{"dropped":2,"errors":[` +
+		`{"index":1,"field":"mobile","code":40002,"message":"mobile is invalid"},` +
+		`{"index":2,"message":"insert row: sql: no rows in result set"},` +
+		`{"field":"name","message":"EOF"}]}}`
+	if string(data) != wantJSON {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+}
+
+func TestMultiErrorResponder(t *testing.T) {
+	m := NewMulti(WithEnvelope(42201, "import failed"))
+	m.AddIndex(0, New(40002, "mobile is invalid"))
+	w := httptest.NewRecorder()
+	NewResponder().Write(w, httptest.NewRequest(http.MethodPost, "/import", nil), fmt.Errorf("import: %w", m))
+	if w.Code != http.StatusUnprocessableEntity || !strings.HasPrefix(w.Body.String(), `{"code":42201,"message":"import failed"`) {
+		t.Errorf("Write() = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(WithLimit(50))
+	for i := 0; i < 100; i++ {
+		c.Go(i, func() error {
+			if i%2 == 0 {
+				return fmt.Errorf("row %d failed", i)
+			}
+			return nil
+		})
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.AddField("header", nil)
+		}()
+	}
+	wg.Wait()
+	err := c.Wait()
+	var m *MultiError
+	if !errors.As(err, &m) || m.Len() != 50 || m.Dropped() != 0 {
+		t.Fatalf("Wait() = %v", err)
+	}
+	entries := m.Entries()
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].Index >= entries[i].Index {
+			t.Fatalf("Wait() entries are not sorted by index")
+		}
+	}
+	if NewCollector().Wait() != nil {
+		t.Errorf("Wait() without errors should be nil")
+	}
+}
@@ -171,6 +171,38 @@ func WriteFileString(filename, data string) error {
 	return WriteFile(filename, []byte(data))
 }
 
+// WriteFileAtomic writes the contents of r to a file named by filename atomically.
+// The data is written to a temporary file in the same directory, synced and renamed over filename,
+// so readers never observe a partially written file. The temporary file is removed on failure.
+// It returns the number of bytes written.
+func WriteFileAtomic(filename string, r io.Reader) (int64, error) {
+	if err := MkdirAll(filename); err != nil {
+		return 0, fmt.Errorf("failed to create parent directories for %s: %w", filename, err)
+	}
+	tmp, err := os.CreateTemp(Dir(filename), "."+Base(filename)+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file for %s: %w", filename, err)
+	}
+	written, err := io.Copy(tmp, r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return written, fmt.Errorf("failed to write file %s: %w", filename, err)
+	}
+	return written, nil
+}
+
 // ReadFile reads the file named by filename and returns the contents.
 func ReadFile(filename string) ([]byte, error) {
 	bytes, err := os.ReadFile(filename)
@@ -2,9 +2,13 @@ package filex
 
 import (
 	"context"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -323,6 +327,34 @@ func TestWriteFile(t *testing.T) {
 	}
 }
 
+func TestWriteFileAtomic(t *testing.T) {
+	tempDir := t.TempDir()
+	filename := filepath.Join(tempDir, "subdir", "test.txt")
+	if err := WriteFileString(filename, "old content"); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := WriteFileAtomic(filename, strings.NewReader("new content"))
+	if err != nil || written != 11 {
+		t.Fatalf("WriteFileAtomic() = %d, %v", written, err)
+	}
+	if content, _ := ReadFileString(filename); content != "new content" {
+		t.Errorf("WriteFileAtomic() wrote %v, want new content", content)
+	}
+
+	// A failing reader must leave the original file and no temporary file behind
+	_, err = WriteFileAtomic(filename, io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(io.ErrUnexpectedEOF)))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("WriteFileAtomic() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if content, _ := ReadFileString(filename); content != "new content" {
+		t.Errorf("WriteFileAtomic() left %v after failure", content)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(filename)); len(entries) != 1 {
+		t.Errorf("WriteFileAtomic() left %d files after failure", len(entries))
+	}
+}
+
 func TestReadFile(t *testing.T) {
 	// Create a temporary file for testing
 	tempFile := filepath.Join(os.TempDir(), "test_readfile.txt")
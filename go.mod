@@ -7,4 +7,8 @@ require (
 	github.com/shopspring/decimal v1.4.0
 	golang.org/x/crypto v0.46.0
 	golang.org/x/exp v0.0.0-20251219203646-944ab1f22d93
+	golang.org/x/net v0.47.0
+	golang.org/x/sys v0.39.0
 )
+
+require golang.org/x/text v0.32.0 // indirect
@@ -0,0 +1,108 @@
+package httpx
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 主机的熔断器处于打开状态，请求未发送
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerConfig 熔断器配置
+type BreakerConfig struct {
+	FailureThreshold int           // 连续失败多少次后打开熔断器，默认5
+	OpenTimeout      time.Duration // 打开后经过多久允许一个探测请求，默认30秒
+}
+
+// breakerState 熔断器状态
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // 关闭，正常请求
+	breakerOpen                         // 打开，拒绝请求
+	breakerHalfOpen                     // 半开，只允许一个探测请求
+)
+
+// hostBreaker 单个主机的熔断器
+type hostBreaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// circuitBreaker 按主机区分的熔断器，网络错误与5xx响应计为失败
+type circuitBreaker struct {
+	mu     sync.Mutex
+	config BreakerConfig
+	hosts  map[string]*hostBreaker
+	now    func() time.Time
+}
+
+// newCircuitBreaker 创建熔断器，未配置的参数使用默认值
+func newCircuitBreaker(config BreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+	return &circuitBreaker{
+		config: config,
+		hosts:  make(map[string]*hostBreaker),
+		now:    time.Now,
+	}
+}
+
+// allow 判断是否允许向主机发送请求，打开状态超时后转为半开并只放行一个探测请求
+func (b *circuitBreaker) allow(host string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	h, found := b.hosts[host]
+	if !found {
+		return true
+	}
+	switch h.state {
+	case breakerOpen:
+		if b.now().Sub(h.openedAt) < b.config.OpenTimeout {
+			return false
+		}
+		h.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// record 记录请求结果，成功时关闭熔断器，半开状态下失败或连续失败达到阈值时打开熔断器
+func (b *circuitBreaker) record(host string, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	h, found := b.hosts[host]
+	if success {
+		if found {
+			delete(b.hosts, host)
+		}
+		return
+	}
+	if !found {
+		h = &hostBreaker{}
+		b.hosts[host] = h
+	}
+	h.failures++
+	if h.state == breakerHalfOpen || h.failures >= b.config.FailureThreshold {
+		h.state = breakerOpen
+		h.openedAt = b.now()
+	}
+}
+
+// release 放弃一次请求的结果，半开状态下恢复为打开状态，使下一个请求可以重新探测
+func (b *circuitBreaker) release(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if h, found := b.hosts[host]; found && h.state == breakerHalfOpen {
+		h.state = breakerOpen
+	}
+}
@@ -2,7 +2,9 @@ package httpx
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/minlib/go-util/jsonx"
 	"io"
@@ -12,50 +14,169 @@ import (
 	"time"
 )
 
-// HttpClient 封装HTTP客户端，可配置超时等参数
+// HttpClient 封装HTTP客户端，可配置超时、重试与熔断等参数
 type HttpClient struct {
-	client *http.Client
+	client          *http.Client
+	retry           *RetryPolicy
+	breaker         *circuitBreaker
+	maxResponseSize int64
+	sleep           func(context.Context, time.Duration) error
+}
+
+// ClientOption HttpClient 的选项
+type ClientOption func(*HttpClient)
+
+// WithRetry 使用重试策略，默认只重试幂等请求
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *HttpClient) {
+		c.retry = &policy
+	}
+}
+
+// WithCircuitBreaker 按主机启用熔断器，连续失败达到阈值后在一段时间内直接返回 ErrCircuitOpen
+func WithCircuitBreaker(config BreakerConfig) ClientOption {
+	return func(c *HttpClient) {
+		c.breaker = newCircuitBreaker(config)
+	}
+}
+
+// WithMaxResponseSize 限制 Request 等缓存响应体的方法读取的最大字节数，超出时返回 ErrBodyTooLarge，不大于0时不限制
+func WithMaxResponseSize(size int64) ClientOption {
+	return func(c *HttpClient) {
+		c.maxResponseSize = size
+	}
 }
 
 // NewHttpClient 创建新的HTTP客户端实例，可指定超时时间
-func NewHttpClient(timeout time.Duration) *HttpClient {
-	return &HttpClient{
+func NewHttpClient(timeout time.Duration, opts ...ClientOption) *HttpClient {
+	c := &HttpClient{
 		client: &http.Client{
 			Timeout: timeout,
 		},
+		sleep: sleepContext,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// Request 发送请求
+// Request 发送请求，配置了重试策略时按策略重试，请求体会被缓存以便重新发送
 func (c *HttpClient) Request(method, requestUrl string, headers map[string]string, body io.Reader) ([]byte, int, error) {
-	req, err := http.NewRequest(method, requestUrl, body)
+	return c.RequestContext(context.Background(), method, requestUrl, headers, body)
+}
+
+// RequestContext 发送请求，ctx 取消或超时时中止请求与重试等待
+func (c *HttpClient) RequestContext(ctx context.Context, method, requestUrl string, headers map[string]string, body io.Reader) ([]byte, int, error) {
+	retry := c.retry != nil && c.retry.retryable(method)
+	var payload []byte
+	if retry && body != nil {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("读取请求体失败: %w", err)
+		}
+		payload = data
+	}
+	for attempt := 1; ; attempt++ {
+		if retry && body != nil {
+			body = bytes.NewReader(payload)
+		}
+		respBody, status, header, err := c.send(ctx, method, requestUrl, headers, body)
+		if !retry || attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return respBody, status, err
+		}
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if !c.retry.retryError(err) {
+				return respBody, status, err
+			}
+			delay = c.retry.backoff(attempt)
+		case c.retry.retryStatus(status):
+			delay = c.retry.backoff(attempt)
+			if after, found := retryAfter(header, time.Now()); found {
+				if c.retry.MaxDelay > 0 && after > c.retry.MaxDelay {
+					return respBody, status, nil
+				}
+				delay = after
+			}
+		default:
+			return respBody, status, nil
+		}
+		if err := c.sleep(ctx, delay); err != nil {
+			return respBody, status, err
+		}
+	}
+}
+
+// send 发送一次请求并读取完整的响应体
+func (c *HttpClient) send(ctx context.Context, method, requestUrl string, headers map[string]string, body io.Reader) ([]byte, int, http.Header, error) {
+	resp, err := c.open(ctx, method, requestUrl, headers, body)
 	if err != nil {
-		return nil, 0, fmt.Errorf("创建请求失败: %w", err)
+		return nil, 0, nil, err
+	}
+	defer resp.Body.Close()
+	reader := io.Reader(resp.Body)
+	if c.maxResponseSize > 0 {
+		reader = limitBody(resp.Body, c.maxResponseSize)
+	}
+	bytes, err := io.ReadAll(reader)
+	if errors.Is(err, ErrBodyTooLarge) {
+		return nil, resp.StatusCode, resp.Header, err
+	}
+	if err != nil {
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("读取响应体失败: %w", &networkError{err})
+	}
+	return bytes, resp.StatusCode, resp.Header, nil
+}
+
+// open 发送一次请求并返回未读取的响应，启用熔断器时先检查主机是否允许请求并记录结果
+func (c *HttpClient) open(ctx context.Context, method, requestUrl string, headers map[string]string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
 	// 添加自定义Header
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
+	if c.breaker != nil && !c.breaker.allow(req.URL.Host) {
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, req.URL.Host)
+	}
 	// 发送请求
 	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, 0, fmt.Errorf("发送请求失败: %w", err)
+	// 调用方的 ctx 取消或超时不代表主机故障，不计入熔断器，但需要释放半开状态下的探测名额；
+	// 客户端的 Timeout 等其他传输错误计为失败
+	if c.breaker != nil {
+		if err != nil && ctx.Err() != nil {
+			c.breaker.release(req.URL.Host)
+		} else {
+			c.breaker.record(req.URL.Host, err == nil && resp.StatusCode < http.StatusInternalServerError)
+		}
 	}
-	defer resp.Body.Close()
-	bytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("读取响应体失败: %w", err)
+		return nil, fmt.Errorf("发送请求失败: %w", &networkError{err})
 	}
-	return bytes, resp.StatusCode, nil
+	return resp, nil
 }
 
 // Get 发送GET请求
 func (c *HttpClient) Get(requestUrl string, headers map[string]string) ([]byte, int, error) {
-	return c.Request(http.MethodGet, requestUrl, headers, nil)
+	return c.GetContext(context.Background(), requestUrl, headers)
+}
+
+// GetContext 发送GET请求，ctx 取消或超时时中止请求
+func (c *HttpClient) GetContext(ctx context.Context, requestUrl string, headers map[string]string) ([]byte, int, error) {
+	return c.RequestContext(ctx, http.MethodGet, requestUrl, headers, nil)
 }
 
 // Post 发送POST请求
 func (c *HttpClient) Post(requestUrl string, headers map[string]string, data interface{}) ([]byte, int, error) {
+	return c.PostContext(context.Background(), requestUrl, headers, data)
+}
+
+// PostContext 发送POST请求，ctx 取消或超时时中止请求
+func (c *HttpClient) PostContext(ctx context.Context, requestUrl string, headers map[string]string, data interface{}) ([]byte, int, error) {
 	jsonBody, err := json.Marshal(data)
 	if err != nil {
 		return nil, 0, fmt.Errorf("JSON序列化失败: %w", err)
@@ -65,11 +186,16 @@ func (c *HttpClient) Post(requestUrl string, headers map[string]string, data int
 		headers = make(map[string]string)
 	}
 	headers["Content-Type"] = "application/json; charset=utf-8"
-	return c.Request(http.MethodPost, requestUrl, headers, body)
+	return c.RequestContext(ctx, http.MethodPost, requestUrl, headers, body)
 }
 
 // PostForm 发送POST表单请求
 func (c *HttpClient) PostForm(requestUrl string, headers map[string]string, data map[string]string) ([]byte, int, error) {
+	return c.PostFormContext(context.Background(), requestUrl, headers, data)
+}
+
+// PostFormContext 发送POST表单请求，ctx 取消或超时时中止请求
+func (c *HttpClient) PostFormContext(ctx context.Context, requestUrl string, headers map[string]string, data map[string]string) ([]byte, int, error) {
 	values := url.Values{}
 	for key, value := range data {
 		values.Set(key, value)
@@ -79,7 +205,19 @@ func (c *HttpClient) PostForm(requestUrl string, headers map[string]string, data
 		headers = make(map[string]string)
 	}
 	headers["Content-Type"] = "application/x-www-form-urlencoded"
-	return c.Request(http.MethodPost, requestUrl, headers, body)
+	return c.RequestContext(ctx, http.MethodPost, requestUrl, headers, body)
+}
+
+// sleepContext 等待指定时间，ctx 先结束时返回 ctx 的错误
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func Get(url string) ([]byte, error) {
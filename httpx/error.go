@@ -0,0 +1,72 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+
+	"github.com/minlib/go-util/errorx"
+)
+
+// maxErrorBody 非标准错误响应保存到 Details 中的最大字节数
+const maxErrorBody = 1024
+
+// DecodeError 将状态码不小于400的响应转为 *errorx.Error，状态码小于400时返回 nil。
+// 响应体为 code 非0的 {code,message,details,traceId} 格式时按原样解析，否则以状态码作为错误码，响应体保存在 Details 的 body 中
+func DecodeError(status int, body []byte) error {
+	if status < http.StatusBadRequest {
+		return nil
+	}
+	if e, err := errorx.Decode(body); err == nil {
+		return e
+	}
+	e := errorx.New(status, http.StatusText(status))
+	if len(body) > 0 {
+		if len(body) > maxErrorBody {
+			body = body[:maxErrorBody]
+		}
+		e = e.WithDetail("body", string(body))
+	}
+	return e
+}
+
+// RequestJSON 发送 JSON 请求并将响应解析到 result，data 与 result 为 nil 时分别不发送与不解析请求体。
+// 状态码不小于400时返回 DecodeError 转换的 *errorx.Error
+func (c *HttpClient) RequestJSON(method, requestUrl string, headers map[string]string, data, result any) error {
+	return c.RequestJSONContext(context.Background(), method, requestUrl, headers, data, result)
+}
+
+// RequestJSONContext 发送 JSON 请求，ctx 取消或超时时中止请求
+func (c *HttpClient) RequestJSONContext(ctx context.Context, method, requestUrl string, headers map[string]string, data, result any) error {
+	var body io.Reader
+	if data != nil {
+		jsonBody, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("JSON序列化失败: %w", err)
+		}
+		body = bytes.NewReader(jsonBody)
+		// 复制调用方的请求头，避免修改调用方可能复用的 map
+		copied := make(map[string]string, len(headers)+1)
+		maps.Copy(copied, headers)
+		copied["Content-Type"] = "application/json; charset=utf-8"
+		headers = copied
+	}
+	respBody, status, err := c.RequestContext(ctx, method, requestUrl, headers, body)
+	if err != nil {
+		return err
+	}
+	if err := DecodeError(status, respBody); err != nil {
+		return err
+	}
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("JSON反序列化失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minlib/go-util/errorx"
+)
+
+func TestRequestJSON(t *testing.T) {
+	ErrUserNotFound := errorx.New(40401, "user not found")
+	server := httptest.NewServer(errorx.DefaultResponder.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		switch r.URL.Path {
+		case "/users/1":
+			w.Header().Set("Content-Type", "application/json")
+			_, err := w.Write([]byte(`{"id":1,"name":"tom"}`))
+			return err
+		case "/users/2":
+			return ErrUserNotFound.WithDetail("id", 2)
+		default:
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return nil
+		}
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(5 * time.Second)
+	var user struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := client.RequestJSON(http.MethodGet, server.URL+"/users/1", nil, nil, &user); err != nil || user.Name != "tom" {
+		t.Errorf("RequestJSON() = %+v, %v", user, err)
+	}
+
+	err := client.RequestJSON(http.MethodGet, server.URL+"/users/2", map[string]string{"X-Trace-Id": "t1"}, nil, &user)
+	var e *errorx.Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrUserNotFound) || e.TraceId != "t1" || e.Details["id"] != float64(2) {
+		t.Errorf("RequestJSON() error = %+v", err)
+	}
+
+	headers := map[string]string{"X-Trace-Id": "t2"}
+	err = client.RequestJSON(http.MethodPost, server.URL+"/orders", headers, map[string]int{"id": 1}, nil)
+	if !errors.As(err, &e) || e.Code != http.StatusBadGateway || e.Details["body"] != "bad gateway\n" {
+		t.Errorf("RequestJSON() error = %+v", err)
+	}
+	// 不修改调用方传入的请求头
+	if len(headers) != 1 || headers["Content-Type"] != "" {
+		t.Errorf("RequestJSON() modified headers: %v", headers)
+	}
+	err = DecodeError(http.StatusNotFound, []byte(`{"message":"Not Found"}`))
+	if errorx.CodeOf(err) != http.StatusNotFound || !errors.As(err, &e) || e.Details["body"] != `{"message":"Not Found"}` {
+		t.Errorf("DecodeError(third-party 404) = %+v", err)
+	}
+	if DecodeError(http.StatusOK, []byte(`{"code":1,"message":"x"}`)) != nil {
+		t.Errorf("DecodeError(200) should be nil")
+	}
+}
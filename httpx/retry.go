@@ -0,0 +1,97 @@
+package httpx
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	MaxAttempts        int           // 最多请求次数，包含第一次请求，不大于1时不重试
+	BaseDelay          time.Duration // 第一次重试前的等待时间，之后每次翻倍
+	MaxDelay           time.Duration // 最长等待时间，Retry-After 超过该时间时不再重试
+	Jitter             float64       // 随机减少等待时间的比例 0-1，避免多个客户端同时重试
+	RetryStatuses      []int         // 需要重试的状态码
+	RetryNetworkErrors bool          // 是否重试网络错误，如连接失败与超时
+	RetryNonIdempotent bool          // 是否重试 POST、PATCH 等非幂等请求，默认只重试幂等请求
+}
+
+// DefaultRetryPolicy 默认的重试策略：最多请求3次，等待 100ms、200ms，重试 429、502、503、504 与网络错误
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:        3,
+		BaseDelay:          100 * time.Millisecond,
+		MaxDelay:           2 * time.Second,
+		Jitter:             0.5,
+		RetryStatuses:      []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		RetryNetworkErrors: true,
+	}
+}
+
+// idempotentMethods 幂等的请求方法
+var idempotentMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete,
+}
+
+// retryable 判断请求方法是否允许重试
+func (p *RetryPolicy) retryable(method string) bool {
+	return p.MaxAttempts > 1 && (p.RetryNonIdempotent || slices.Contains(idempotentMethods, method))
+}
+
+// retryStatus 判断状态码是否需要重试
+func (p *RetryPolicy) retryStatus(status int) bool {
+	return slices.Contains(p.RetryStatuses, status)
+}
+
+// retryError 判断错误是否需要重试，只重试网络错误，创建请求失败与熔断错误不重试
+func (p *RetryPolicy) retryError(err error) bool {
+	var netErr *networkError
+	return p.RetryNetworkErrors && errors.As(err, &netErr)
+}
+
+// networkError 发送请求或读取响应时的网络错误
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string {
+	return e.err.Error()
+}
+
+func (e *networkError) Unwrap() error {
+	return e.err
+}
+
+// backoff 计算第 retry 次重试（从1开始）前的等待时间：指数退避并随机减少 Jitter 比例
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if jitter := int64(float64(delay) * p.Jitter); jitter > 0 {
+		delay -= time.Duration(rand.Int64N(jitter + 1))
+	}
+	return delay
+}
+
+// retryAfter 解析 Retry-After 响应头，支持秒数与 HTTP 日期，不存在或无效时返回 false
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
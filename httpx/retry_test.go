@@ -0,0 +1,239 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryClient 创建记录等待时间而不实际等待的客户端
+func newRetryClient(delays *[]time.Duration, opts ...ClientOption) *HttpClient {
+	c := NewHttpClient(time.Second, opts...)
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	return c
+}
+
+func TestRequestRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	policy := DefaultRetryPolicy()
+	policy.Jitter = 0
+	client := newRetryClient(&delays, WithRetry(policy))
+	body, status, err := client.Request(http.MethodPut, server.URL, nil, strings.NewReader("payload"))
+	if err != nil || status != http.StatusOK || string(body) != "payload" || calls.Load() != 3 {
+		t.Errorf("Request() = %s, %d, %v, calls %d", body, status, err, calls.Load())
+	}
+	if len(delays) != 2 || delays[0] != 100*time.Millisecond || delays[1] != 200*time.Millisecond {
+		t.Errorf("backoff delays = %v", delays)
+	}
+
+	// 非幂等请求默认不重试
+	calls.Store(0)
+	_, status, _ = client.Request(http.MethodPost, server.URL, nil, strings.NewReader("payload"))
+	if status != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("POST status = %d, calls %d", status, calls.Load())
+	}
+	policy.RetryNonIdempotent = true
+	calls.Store(0)
+	_, status, _ = newRetryClient(&delays, WithRetry(policy)).Request(http.MethodPost, server.URL, nil, strings.NewReader("payload"))
+	if status != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("POST with RetryNonIdempotent status = %d, calls %d", status, calls.Load())
+	}
+}
+
+func TestRequestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 5
+	_, status, err := newRetryClient(&delays, WithRetry(policy)).Request(http.MethodGet, server.URL, nil, nil)
+	if err != nil || status != http.StatusTooManyRequests || calls.Load() != 2 {
+		t.Errorf("Request() = %d, %v, calls %d", status, err, calls.Load())
+	}
+	if len(delays) != 1 || delays[0] != time.Second {
+		t.Errorf("Retry-After delays = %v", delays)
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	header := http.Header{"Retry-After": {now.Add(3 * time.Second).Format(http.TimeFormat)}}
+	if d, ok := retryAfter(header, now); !ok || d != 3*time.Second {
+		t.Errorf("retryAfter(date) = %v, %v", d, ok)
+	}
+}
+
+func TestRequestRetryNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	serverUrl := server.URL
+	server.Close()
+
+	var delays []time.Duration
+	_, _, err := newRetryClient(&delays, WithRetry(DefaultRetryPolicy())).Request(http.MethodGet, serverUrl, nil, nil)
+	if err == nil || len(delays) != 2 {
+		t.Errorf("Request() = %v, delays %v", err, delays)
+	}
+	delays = nil
+	_, _, err = newRetryClient(&delays, WithRetry(DefaultRetryPolicy())).Request(http.MethodGet, "http://a b/", nil, nil)
+	if err == nil || len(delays) != 0 {
+		t.Errorf("Request(invalid url) = %v, delays %v", err, delays)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.5}
+	for retry := 1; retry <= 6; retry++ {
+		want := min(100*time.Millisecond<<(retry-1), time.Second)
+		for i := 0; i < 20; i++ {
+			if d := policy.backoff(retry); d < want/2 || d > want {
+				t.Fatalf("backoff(%d) = %v, want [%v, %v]", retry, d, want/2, want)
+			}
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	client := NewHttpClient(time.Second, WithCircuitBreaker(BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Minute}))
+	client.breaker.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		if _, status, _ := client.Get(server.URL, nil); status != http.StatusInternalServerError {
+			t.Fatalf("Get() status = %d", status)
+		}
+	}
+	if _, _, err := client.Get(server.URL, nil); !errors.Is(err, ErrCircuitOpen) || calls.Load() != 3 {
+		t.Fatalf("Get() on open circuit = %v, calls %d", err, calls.Load())
+	}
+
+	// 超时后只放行一个探测请求，探测失败重新打开
+	now = now.Add(time.Minute)
+	if _, status, _ := client.Get(server.URL, nil); status != http.StatusInternalServerError || calls.Load() != 4 {
+		t.Fatalf("probe status = %d, calls %d", status, calls.Load())
+	}
+	if _, _, err := client.Get(server.URL, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Get() after failed probe = %v", err)
+	}
+
+	// 探测成功后关闭
+	now = now.Add(time.Minute)
+	healthy.Store(true)
+	for i := 0; i < 3; i++ {
+		if _, status, err := client.Get(server.URL, nil); err != nil || status != http.StatusOK {
+			t.Fatalf("Get() after recovery = %d, %v", status, err)
+		}
+	}
+
+	// 熔断错误不重试
+	var delays []time.Duration
+	retryClient := newRetryClient(&delays, WithRetry(DefaultRetryPolicy()), WithCircuitBreaker(BreakerConfig{FailureThreshold: 1}))
+	healthy.Store(false)
+	retryClient.Get(server.URL, nil)
+	if _, _, err := retryClient.Get(server.URL, nil); !errors.Is(err, ErrCircuitOpen) || len(delays) != 0 {
+		t.Errorf("retry on open circuit = %v, delays %v", err, delays)
+	}
+}
+
+func TestCircuitBreakerClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	// 客户端的 Timeout 表示上游响应过慢，需要计为失败
+	client := NewHttpClient(20*time.Millisecond, WithCircuitBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute}))
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Get(server.URL, nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Get() #%d = %v, want timeout", i, err)
+		}
+	}
+	if _, _, err := client.Get(server.URL, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Get() after client timeouts = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreakerIgnoresCallerCancel(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Query().Get("slow") != "" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	client := NewHttpClient(10*time.Second, WithCircuitBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute}))
+	client.breaker.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, _, err := client.GetContext(ctx, server.URL+"?slow=1", nil)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("GetContext() = %v", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := client.GetContext(ctx, server.URL, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetContext(canceled) = %v", err)
+	}
+	if _, status, err := client.Get(server.URL, nil); err != nil || status != http.StatusOK {
+		t.Fatalf("Get() after caller timeouts = %d, %v", status, err)
+	}
+
+	// 半开状态下被调用方取消的探测请求不占用探测名额
+	client.breaker.record(server.URL[len("http://"):], false)
+	now = now.Add(time.Minute)
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	client.GetContext(ctx, server.URL+"?slow=1", nil)
+	cancel()
+	if _, status, err := client.Get(server.URL, nil); err != nil || status != http.StatusOK {
+		t.Errorf("probe after canceled probe = %d, %v", status, err)
+	}
+}
@@ -0,0 +1,146 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/minlib/go-util/filex"
+)
+
+// ErrBodyTooLarge 响应体超过限制的大小
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// limitedBody 限制可读取字节数的响应体，超出时返回 ErrBodyTooLarge 而不是静默截断
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+// limitBody 限制响应体最多读取 limit 个字节
+func limitBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	return &limitedBody{ReadCloser: body, remaining: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// 已达到限制，再读一个字节判断是否还有数据
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// Stream 发送请求并返回未读取的响应体，调用方负责关闭。maxBytes 大于0时限制读取的字节数，
+// Content-Length 超出限制时直接返回 ErrBodyTooLarge，否则读取超出限制时 Read 返回 ErrBodyTooLarge。
+// 流式请求不会重试，但会经过熔断器
+func (c *HttpClient) Stream(ctx context.Context, method, requestUrl string, headers map[string]string, body io.Reader, maxBytes int64) (io.ReadCloser, int, error) {
+	resp, err := c.stream(ctx, method, requestUrl, headers, body, maxBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, resp.StatusCode, nil
+}
+
+// stream 发送请求并返回响应，maxBytes 大于0时限制响应体的字节数
+func (c *HttpClient) stream(ctx context.Context, method, requestUrl string, headers map[string]string, body io.Reader, maxBytes int64) (*http.Response, error) {
+	resp, err := c.open(ctx, method, requestUrl, headers, body)
+	if err != nil || maxBytes <= 0 {
+		return resp, err
+	}
+	if resp.ContentLength > maxBytes {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d bytes", ErrBodyTooLarge, resp.ContentLength)
+	}
+	resp.Body = limitBody(resp.Body, maxBytes)
+	return resp, nil
+}
+
+// downloadOptions Download 的选项
+type downloadOptions struct {
+	headers  map[string]string
+	maxBytes int64
+	progress func(written, total int64)
+}
+
+// DownloadOption Download 的选项
+type DownloadOption func(*downloadOptions)
+
+// WithDownloadHeaders 下载请求的请求头
+func WithDownloadHeaders(headers map[string]string) DownloadOption {
+	return func(o *downloadOptions) {
+		o.headers = headers
+	}
+}
+
+// WithMaxDownloadSize 限制下载的最大字节数，超出时返回 ErrBodyTooLarge 且不会生成文件
+func WithMaxDownloadSize(maxBytes int64) DownloadOption {
+	return func(o *downloadOptions) {
+		o.maxBytes = maxBytes
+	}
+}
+
+// WithProgress 下载进度回调，written 为已写入的字节数，total 为响应的 Content-Length，未知时为 -1
+func WithProgress(progress func(written, total int64)) DownloadOption {
+	return func(o *downloadOptions) {
+		o.progress = progress
+	}
+}
+
+// progressReader 读取时回调下载进度
+type progressReader struct {
+	reader   io.Reader
+	written  int64
+	total    int64
+	progress func(written, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.written += int64(n)
+		r.progress(r.written, r.total)
+	}
+	return n, err
+}
+
+// Download 使用 GET 请求下载文件，通过 filex.WriteFileAtomic 先写入临时文件再重命名，
+// 失败或被取消时不会留下不完整的文件。状态码不小于400时返回 DecodeError 转换的 *errorx.Error，返回写入的字节数
+func (c *HttpClient) Download(ctx context.Context, requestUrl, filename string, opts ...DownloadOption) (int64, error) {
+	o := &downloadOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	resp, err := c.stream(ctx, http.MethodGet, requestUrl, o.headers, nil, o.maxBytes)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		if err := DecodeError(resp.StatusCode, data); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("下载失败，状态码: %d", resp.StatusCode)
+	}
+	reader := io.Reader(resp.Body)
+	if o.progress != nil {
+		reader = &progressReader{reader: reader, total: resp.ContentLength, progress: o.progress}
+	}
+	written, err := filex.WriteFileAtomic(filename, reader)
+	if err != nil {
+		return written, fmt.Errorf("下载失败: %w", err)
+	}
+	return written, nil
+}
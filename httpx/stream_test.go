@@ -0,0 +1,128 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minlib/go-util/errorx"
+)
+
+func newStreamServer() *httptest.Server {
+	content := strings.Repeat("0123456789", 1000)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/file":
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = io.WriteString(w, content)
+		case "/chunked":
+			// 不设置 Content-Length，逐块写入
+			for i := 0; i < 10; i++ {
+				_, _ = io.WriteString(w, content[:1000])
+				w.(http.Flusher).Flush()
+			}
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		case "/missing":
+			errorx.WriteError(w, r, errorx.New(40401, "file not found"))
+		}
+	}))
+}
+
+func TestRequestContext(t *testing.T) {
+	server := newStreamServer()
+	defer server.Close()
+
+	client := NewHttpClient(10*time.Second, WithRetry(DefaultRetryPolicy()))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := client.GetContext(ctx, server.URL+"/slow", nil)
+	if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 2*time.Second {
+		t.Errorf("GetContext() = %v after %v", err, time.Since(start))
+	}
+
+	if err := sleepContext(ctx, time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("sleepContext() = %v", err)
+	}
+
+	limited := NewHttpClient(time.Second, WithMaxResponseSize(100))
+	if _, _, err := limited.Get(server.URL+"/file", nil); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Get() with max response size = %v", err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	server := newStreamServer()
+	defer server.Close()
+
+	client := NewHttpClient(time.Second)
+	body, status, err := client.Stream(context.Background(), http.MethodGet, server.URL+"/chunked", nil, nil, 10000)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("Stream() = %d, %v", status, err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || len(data) != 10000 {
+		t.Errorf("ReadAll() = %d bytes, %v", len(data), err)
+	}
+
+	// Content-Length 超出限制时不返回响应体
+	if _, _, err := client.Stream(context.Background(), http.MethodGet, server.URL+"/file", nil, nil, 100); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Stream(/file) = %v", err)
+	}
+	// 没有 Content-Length 时读取超出限制返回错误
+	body, _, err = client.Stream(context.Background(), http.MethodGet, server.URL+"/chunked", nil, nil, 2500)
+	if err != nil {
+		t.Fatalf("Stream(/chunked) = %v", err)
+	}
+	defer body.Close()
+	if data, err := io.ReadAll(body); !errors.Is(err, ErrBodyTooLarge) || len(data) != 2500 {
+		t.Errorf("ReadAll() = %d bytes, %v", len(data), err)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	server := newStreamServer()
+	defer server.Close()
+
+	client := NewHttpClient(time.Second)
+	filename := filepath.Join(t.TempDir(), "downloads", "file.txt")
+	var calls int
+	var last, total int64
+	written, err := client.Download(context.Background(), server.URL+"/file", filename, WithProgress(func(w, t int64) {
+		calls++
+		last, total = w, t
+	}))
+	if err != nil || written != 10000 || last != 10000 || total != 10000 || calls == 0 {
+		t.Fatalf("Download() = %d, %v, progress %d/%d", written, err, last, total)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Size() != 10000 {
+		t.Errorf("downloaded file = %v, %v", info, err)
+	}
+
+	// 失败时保留原文件且不留下临时文件
+	_, err = client.Download(context.Background(), server.URL+"/chunked", filename, WithMaxDownloadSize(5000))
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Download() with max size = %v", err)
+	}
+	_, err = client.Download(context.Background(), server.URL+"/missing", filename)
+	if !errors.Is(err, errorx.New(40401, "")) {
+		t.Errorf("Download(/missing) = %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(filename))
+	if info, _ := os.Stat(filename); len(entries) != 1 || info.Size() != 10000 {
+		t.Errorf("Download() failures left %d files", len(entries))
+	}
+}
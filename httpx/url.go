@@ -1,14 +1,70 @@
 package httpx
 
 import (
+	"errors"
+	"fmt"
+	"net/netip"
 	netUrl "net/url"
+
+	"github.com/minlib/go-util/check"
 )
 
-func RawUrl(url string, params map[string][]string) (string, error) {
+// ErrUrlDenied URL 的主机属于禁止访问的网段
+var ErrUrlDenied = errors.New("url host is in a denied cidr")
+
+// urlOptions RawUrl 的校验选项
+type urlOptions struct {
+	validate bool     // 是否校验 URL
+	schemes  []string // 允许的协议
+	denied   []string // 禁止访问的网段
+}
+
+// UrlOption RawUrl 的选项
+type UrlOption func(*urlOptions)
+
+// WithSchemes 拼接前使用 check.ValidateUrl 校验 URL，协议必须在 schemes 中，未指定时只允许 http 与 https
+func WithSchemes(schemes ...string) UrlOption {
+	return func(o *urlOptions) {
+		o.validate = true
+		o.schemes = schemes
+	}
+}
+
+// WithDeniedCIDRs 拼接前校验 URL，并拒绝主机为 IP 地址且属于任一网段的 URL，如回调地址禁止指向内网。
+// 主机名不会被解析，只检查 IP 形式的主机
+func WithDeniedCIDRs(cidrs ...string) UrlOption {
+	return func(o *urlOptions) {
+		o.validate = true
+		o.denied = append(o.denied, cidrs...)
+	}
+}
+
+// RawUrl 将参数追加到 URL 的查询字符串，指定选项时会在拼接前校验 URL
+func RawUrl(url string, params map[string][]string, opts ...UrlOption) (string, error) {
+	o := &urlOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.validate {
+		if err := check.ValidateUrl(url, o.schemes...); err != nil {
+			return "", err
+		}
+	}
 	u, err := netUrl.Parse(url)
 	if err != nil {
 		return "", err
 	}
+	if len(o.denied) > 0 {
+		if addr, err := netip.ParseAddr(u.Hostname()); err == nil {
+			err = check.ValidateIPInCIDR(addr.String(), o.denied...)
+			if err == nil {
+				return "", fmt.Errorf("%w: %s", ErrUrlDenied, u.Hostname())
+			}
+			if !errors.Is(err, check.ErrIPNotInCIDR) {
+				return "", err
+			}
+		}
+	}
 	values := u.Query()
 	for key, v := range params {
 		for _, value := range v {
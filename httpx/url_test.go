@@ -1,9 +1,12 @@
 package httpx
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"testing"
+
+	"github.com/minlib/go-util/check"
 )
 
 func TestRawUrl(t *testing.T) {
@@ -17,3 +20,30 @@ func TestRawUrl(t *testing.T) {
 		fmt.Println(rawUrl)
 	}
 }
+
+func TestRawUrlWithOptions(t *testing.T) {
+	params := url.Values{"code": {"abc"}}
+	denied := WithDeniedCIDRs("10.0.0.0/8", "127.0.0.0/8", "::1/128")
+	tests := []struct {
+		url  string
+		opts []UrlOption
+		want error
+	}{
+		{"https://example.com/notify", []UrlOption{WithSchemes()}, nil},
+		{"https://93.184.216.34/notify", []UrlOption{denied}, nil},
+		{"ftp://example.com/notify", []UrlOption{WithSchemes()}, check.ErrUrlScheme},
+		{"/notify", []UrlOption{WithSchemes("https")}, check.ErrUrlNotAbsolute},
+		{"http://10.1.2.3/notify", []UrlOption{denied}, ErrUrlDenied},
+		{"http://[::1]:8080/notify", []UrlOption{denied}, ErrUrlDenied},
+		{"http://127.0.0.1/notify", []UrlOption{WithDeniedCIDRs("127.0.0.0/33")}, check.ErrCIDRFormat},
+	}
+	for _, tt := range tests {
+		if _, err := RawUrl(tt.url, params, tt.opts...); !errors.Is(err, tt.want) {
+			t.Errorf("RawUrl(%s) = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+	rawUrl, err := RawUrl("https://example.com/notify?a=1", params, WithSchemes("https"))
+	if err != nil || rawUrl != "https://example.com/notify?a=1&code=abc" {
+		t.Errorf("RawUrl() = %s, %v", rawUrl, err)
+	}
+}
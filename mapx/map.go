@@ -55,204 +55,221 @@ func Get(m map[string]interface{}, key string) (interface{}, bool) {
 //
 //	成功获取的字符串或默认值
 func GetString(m map[string]interface{}, key string, defaultValue string) string {
-	// 处理nil map
-	if m == nil {
-		return defaultValue
+	if str, ok := AsString(m[key]); ok {
+		return str
 	}
+	return defaultValue
+}
 
-	// 获取原始值
-	val, exists := m[key]
-	if !exists {
-		return defaultValue
+// GetInt 从map中安全获取整数值
+// 参数:
+//
+//	m: 源map，允许为nil
+//	key: 要获取的键
+//	defaultValue: 当键不存在或转换失败时返回的默认值
+//
+// 返回:
+//
+//	成功获取的整数或默认值
+func GetInt(m map[string]interface{}, key string, defaultValue int) int {
+	if num, ok := AsInt(m[key]); ok {
+		return num
 	}
+	return defaultValue
+}
 
+// GetFloat 从map中安全获取浮点数值
+// 参数:
+//
+//	m: 源map，允许为nil
+//	key: 要获取的键
+//	defaultValue: 当键不存在或转换失败时返回的默认值
+//
+// 返回:
+//
+//	成功获取的浮点数或默认值
+func GetFloat(m map[string]interface{}, key string, defaultValue float64) float64 {
+	if num, ok := AsFloat(m[key]); ok {
+		return num
+	}
+	return defaultValue
+}
+
+// GetBool 从map中安全获取布尔值
+// 参数:
+//
+//	m: 源map，允许为nil
+//	key: 要获取的键
+//	defaultValue: 当键不存在或转换失败时返回的默认值
+//
+// 返回:
+//
+//	成功获取的布尔值或默认值
+func GetBool(m map[string]interface{}, key string, defaultValue bool) bool {
+	if b, ok := AsBool(m[key]); ok {
+		return b
+	}
+	return defaultValue
+}
+
+// AsString 将值转换为字符串，GetString 使用相同的转换规则
+// 数字与布尔值按十进制与 true/false 格式化，其他类型使用默认格式，nil 返回 false
+func AsString(val interface{}) (string, bool) {
 	// 空值处理
 	if val == nil {
-		return defaultValue
+		return "", false
 	}
 
 	// 直接返回字符串类型
 	if str, ok := val.(string); ok {
-		return str
+		return str, true
 	}
 
 	// 处理数字类型转换
 	switch num := val.(type) {
 	case int:
-		return strconv.Itoa(num)
+		return strconv.Itoa(num), true
 	case int8:
-		return strconv.FormatInt(int64(num), 10)
+		return strconv.FormatInt(int64(num), 10), true
 	case int16:
-		return strconv.FormatInt(int64(num), 10)
+		return strconv.FormatInt(int64(num), 10), true
 	case int32:
-		return strconv.FormatInt(int64(num), 10)
+		return strconv.FormatInt(int64(num), 10), true
 	case int64:
-		return strconv.FormatInt(num, 10)
+		return strconv.FormatInt(num, 10), true
 	case uint:
-		return strconv.FormatUint(uint64(num), 10)
+		return strconv.FormatUint(uint64(num), 10), true
 	case uint8:
-		return strconv.FormatUint(uint64(num), 10)
+		return strconv.FormatUint(uint64(num), 10), true
 	case uint16:
-		return strconv.FormatUint(uint64(num), 10)
+		return strconv.FormatUint(uint64(num), 10), true
 	case uint32:
-		return strconv.FormatUint(uint64(num), 10)
+		return strconv.FormatUint(uint64(num), 10), true
 	case uint64:
-		return strconv.FormatUint(num, 10)
+		return strconv.FormatUint(num, 10), true
 	case float32:
-		return strconv.FormatFloat(float64(num), 'f', -1, 32)
+		return strconv.FormatFloat(float64(num), 'f', -1, 32), true
 	case float64:
-		return strconv.FormatFloat(num, 'f', -1, 64)
+		return strconv.FormatFloat(num, 'f', -1, 64), true
 	}
 
 	// 处理布尔类型
 	if b, ok := val.(bool); ok {
-		return strconv.FormatBool(b)
+		return strconv.FormatBool(b), true
 	}
 
 	// 其他类型使用默认格式转换
-	return fmt.Sprintf("%v", val)
+	return fmt.Sprintf("%v", val), true
 }
 
-// GetInt 从map中安全获取整数值
-// 参数:
-//
-//	m: 源map，允许为nil
-//	key: 要获取的键
-//	defaultValue: 当键不存在或转换失败时返回的默认值
-//
-// 返回:
-//
-//	成功获取的整数或默认值
-func GetInt(m map[string]interface{}, key string, defaultValue int) int {
-	// 处理nil map
-	if m == nil {
-		return defaultValue
-	}
-
-	// 获取原始值
-	val, exists := m[key]
-	if !exists {
-		return defaultValue
-	}
-
-	// 空值处理
-	if val == nil {
-		return defaultValue
-	}
-
+// AsInt 将值转换为整数，GetInt 使用相同的转换规则
+// 整数直接转换，浮点数截断小数部分，字符串按十进制解析，其他类型返回 false
+func AsInt(val interface{}) (int, bool) {
 	// 处理整数类型
 	switch num := val.(type) {
 	case int:
-		return num
+		return num, true
 	case int8:
-		return int(num)
+		return int(num), true
 	case int16:
-		return int(num)
+		return int(num), true
 	case int32:
-		return int(num)
+		return int(num), true
 	case int64:
-		return int(num)
+		return int(num), true
 	case uint:
-		return int(num)
+		return int(num), true
 	case uint8:
-		return int(num)
+		return int(num), true
 	case uint16:
-		return int(num)
+		return int(num), true
 	case uint32:
-		return int(num)
+		return int(num), true
 	case uint64:
-		return int(num)
+		return int(num), true
 	}
 
 	// 处理浮点类型（截断小数部分）
 	switch num := val.(type) {
 	case float32:
-		return int(num)
+		return int(num), true
 	case float64:
-		return int(num)
+		return int(num), true
 	}
 
 	// 处理字符串类型（尝试解析为整数）
 	if str, ok := val.(string); ok {
 		num, err := strconv.Atoi(str)
 		if err == nil {
-			return num
+			return num, true
 		}
 	}
 
-	// 其他类型返回默认值
-	return defaultValue
+	// 其他类型返回 false
+	return 0, false
 }
 
-// GetFloat 从map中安全获取浮点数值
-// 参数:
-//
-//	m: 源map，允许为nil
-//	key: 要获取的键
-//	defaultValue: 当键不存在或转换失败时返回的默认值
-//
-// 返回:
-//
-//	成功获取的浮点数或默认值
-func GetFloat(m map[string]interface{}, key string, defaultValue float64) float64 {
-	// 处理nil map
-	if m == nil {
-		return defaultValue
-	}
-
-	// 获取原始值
-	val, exists := m[key]
-	if !exists {
-		return defaultValue
-	}
-
-	// 空值处理
-	if val == nil {
-		return defaultValue
-	}
-
+// AsFloat 将值转换为浮点数，GetFloat 使用相同的转换规则
+// 数字直接转换，字符串按十进制解析，其他类型返回 false
+func AsFloat(val interface{}) (float64, bool) {
 	// 处理整数类型
 	switch num := val.(type) {
 	case int:
-		return float64(num)
+		return float64(num), true
 	case int8:
-		return float64(num)
+		return float64(num), true
 	case int16:
-		return float64(num)
+		return float64(num), true
 	case int32:
-		return float64(num)
+		return float64(num), true
 	case int64:
-		return float64(num)
+		return float64(num), true
 	case uint:
-		return float64(num)
+		return float64(num), true
 	case uint8:
-		return float64(num)
+		return float64(num), true
 	case uint16:
-		return float64(num)
+		return float64(num), true
 	case uint32:
-		return float64(num)
+		return float64(num), true
 	case uint64:
-		return float64(num)
+		return float64(num), true
 	}
 
 	// 处理浮点类型
 	switch num := val.(type) {
 	case float32:
-		return float64(num)
+		return float64(num), true
 	case float64:
-		return num
+		return num, true
 	}
 
 	// 处理字符串类型（尝试解析为浮点数）
 	if str, ok := val.(string); ok {
 		num, err := strconv.ParseFloat(str, 64)
 		if err == nil {
-			return num
+			return num, true
 		}
 	}
 
-	// 其他类型返回默认值
-	return defaultValue
+	// 其他类型返回 false
+	return 0, false
+}
+
+// AsBool 将值转换为布尔值，GetBool 使用相同的转换规则
+// 数字非零为 true，字符串按 strconv.ParseBool 解析，其他类型返回 false
+func AsBool(val interface{}) (bool, bool) {
+	if b, ok := val.(bool); ok {
+		return b, true
+	}
+	if str, ok := val.(string); ok {
+		b, err := strconv.ParseBool(str)
+		return b, err == nil
+	}
+	if num, ok := AsFloat(val); ok {
+		return num != 0, true
+	}
+	return false, false
 }
 
 // GetStringSlice 将map中的值转换为字符串切片
@@ -305,6 +305,61 @@ func TestGetFloat(t *testing.T) {
 	}
 }
 
+func TestGetBool(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputMap     map[string]interface{}
+		key          string
+		defaultValue bool
+		expected     bool
+	}{
+		{
+			name:         "get bool",
+			inputMap:     map[string]interface{}{"enabled": true},
+			key:          "enabled",
+			defaultValue: false,
+			expected:     true,
+		},
+		{
+			name:         "get string bool",
+			inputMap:     map[string]interface{}{"enabled": "false"},
+			key:          "enabled",
+			defaultValue: true,
+			expected:     false,
+		},
+		{
+			name:         "get number as bool",
+			inputMap:     map[string]interface{}{"enabled": float64(1)},
+			key:          "enabled",
+			defaultValue: false,
+			expected:     true,
+		},
+		{
+			name:         "invalid string to bool",
+			inputMap:     map[string]interface{}{"enabled": "yes"},
+			key:          "enabled",
+			defaultValue: true,
+			expected:     true,
+		},
+		{
+			name:         "handle nil map",
+			inputMap:     nil,
+			key:          "enabled",
+			defaultValue: true,
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetBool(tt.inputMap, tt.key, tt.defaultValue)
+			if result != tt.expected {
+				t.Errorf("GetBool() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetStringSlice(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,36 @@
+package sequence
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// lockFile 通过操作系统的文件锁（Unix 的 flock，Windows 的 LockFileEx）实现跨进程互斥，
+// 进程退出时锁由操作系统自动释放，不会残留。锁文件本身保留在磁盘上，等待超过 timeout 返回错误。
+// 返回的函数用于释放锁
+func lockFile(path string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s: %w", path, err)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLock(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to lock file %s: %w", path, err)
+		}
+		if locked {
+			return func() {
+				_ = unlockFile(f)
+				_ = f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, fmt.Errorf("timeout waiting for lock file %s", path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
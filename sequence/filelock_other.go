@@ -0,0 +1,20 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// tryLock 当前平台不支持文件锁
+func tryLock(f *os.File) (bool, error) {
+	return false, fmt.Errorf("file lock is not supported on %s: %w", runtime.GOOS, errors.ErrUnsupported)
+}
+
+// unlockFile 当前平台不支持文件锁
+func unlockFile(f *os.File) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,69 @@
+package sequence
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	release, err := lockFile(path, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lockFile(path, 50*time.Millisecond); err == nil {
+		t.Fatal("lockFile() should time out while the lock is held")
+	}
+	release()
+	release, err = lockFile(path, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("lockFile() after release = %v", err)
+	}
+	release()
+}
+
+func TestLockFileLeftover(t *testing.T) {
+	// 旧的锁文件（如进程崩溃后留下的）不持有锁，不会阻塞
+	path := filepath.Join(t.TempDir(), "test.lock")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	release, err := lockFile(path, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("lockFile() with leftover file = %v", err)
+	}
+	release()
+}
+
+func TestLockFileConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+	var holders, overlaps atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				release, err := lockFile(path, 10*time.Second)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if holders.Add(1) > 1 {
+					overlaps.Add(1)
+				}
+				time.Sleep(100 * time.Microsecond)
+				holders.Add(-1)
+				release()
+			}
+		}()
+	}
+	wg.Wait()
+	if n := overlaps.Load(); n > 0 {
+		t.Errorf("lock held by %d holders at the same time", n)
+	}
+}
@@ -0,0 +1,59 @@
+package sequence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatSnowflake = "snowflake" // 雪花算法 int64 ID
+	FormatULID      = "ulid"      // ULID，26位 Crockford Base32
+	FormatUUIDv7    = "uuidv7"    // UUID version 7
+	FormatKSUID     = "ksuid"     // KSUID，27位 Base62
+)
+
+// IDGenerator 字符串ID生成器，各种格式的ID均按生成时间有序
+type IDGenerator interface {
+	// NextString 生成一个新的ID
+	NextString() (string, error)
+	// ParseTime 解析ID的生成时间，ID格式不正确时返回错误
+	ParseTime(id string) (time.Time, error)
+}
+
+// NewIDGenerator 根据格式名称创建ID生成器，便于通过配置切换ID格式
+// @format snowflake、ulid、uuidv7、ksuid，不区分大小写
+// @opts 雪花算法的配置项，其他格式忽略
+func NewIDGenerator(format string, opts ...Option) (IDGenerator, error) {
+	switch strings.ToLower(format) {
+	case FormatSnowflake:
+		return NewWithOptions(opts...)
+	case FormatULID:
+		return NewULIDGenerator(), nil
+	case FormatUUIDv7:
+		return NewUUIDv7Generator(), nil
+	case FormatKSUID:
+		return NewKSUIDGenerator(), nil
+	default:
+		return nil, fmt.Errorf("unsupported id format %q", format)
+	}
+}
+
+// NextString 生成ID的十进制字符串
+func (s *Sequence) NextString() (string, error) {
+	id, err := s.Generate()
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(id, 10), nil
+}
+
+// ParseTime 解析十进制字符串ID的生成时间
+func (s *Sequence) ParseTime(id string) (time.Time, error) {
+	value, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || value < 0 {
+		return time.Time{}, fmt.Errorf("invalid snowflake id %q", id)
+	}
+	return s.Time(value), nil
+}
@@ -0,0 +1,31 @@
+package sequence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewIDGenerator(t *testing.T) {
+	for _, format := range []string{FormatSnowflake, FormatULID, FormatUUIDv7, FormatKSUID} {
+		t.Run(format, func(t *testing.T) {
+			g, err := NewIDGenerator(format, WithWorkerId(3))
+			if err != nil {
+				t.Fatal(err)
+			}
+			id, err := g.NextString()
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := g.ParseTime(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if time.Since(parsed) > time.Minute {
+				t.Errorf("ParseTime(%s) = %v", id, parsed)
+			}
+		})
+	}
+	if _, err := NewIDGenerator("unknown"); err == nil {
+		t.Errorf("NewIDGenerator() should fail for unknown format")
+	}
+}
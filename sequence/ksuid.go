@@ -0,0 +1,115 @@
+package sequence
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// base62Alphabet KSUID 使用的 Base62 字符表
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// ksuidEpoch KSUID 的开始时间 2014-05-13 16:53:20 UTC
+	ksuidEpoch int64 = 1400000000
+	// ksuidLength KSUID 编码后的长度
+	ksuidLength = 27
+)
+
+// KSUID 160位按时间排序的唯一标识：32位秒级时间戳 + 128位随机数
+type KSUID [20]byte
+
+// String 返回27位 Base62 编码
+func (k KSUID) String() string {
+	n := new(big.Int).SetBytes(k[:])
+	base := big.NewInt(62)
+	mod := new(big.Int)
+	var buf [ksuidLength]byte
+	for i := ksuidLength - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		buf[i] = base62Alphabet[mod.Int64()]
+	}
+	return string(buf[:])
+}
+
+// Timestamp 返回秒级 Unix 时间戳
+func (k KSUID) Timestamp() int64 {
+	return int64(uint32(k[0])<<24|uint32(k[1])<<16|uint32(k[2])<<8|uint32(k[3])) + ksuidEpoch
+}
+
+// Time 返回生成时间
+func (k KSUID) Time() time.Time {
+	return time.Unix(k.Timestamp(), 0)
+}
+
+// NewKSUID 生成 KSUID
+func NewKSUID() (KSUID, error) {
+	var k KSUID
+	timestamp := time.Now().Unix() - ksuidEpoch
+	if timestamp < 0 || timestamp > 1<<32-1 {
+		return k, fmt.Errorf("%w: %d", ErrTimestampOverflow, timestamp)
+	}
+	k[0] = byte(timestamp >> 24)
+	k[1] = byte(timestamp >> 16)
+	k[2] = byte(timestamp >> 8)
+	k[3] = byte(timestamp)
+	if _, err := rand.Read(k[4:]); err != nil {
+		return k, fmt.Errorf("failed to read random: %w", err)
+	}
+	return k, nil
+}
+
+// ParseKSUID 解析27位 Base62 编码的 KSUID
+func ParseKSUID(s string) (KSUID, error) {
+	var k KSUID
+	if len(s) != ksuidLength {
+		return k, fmt.Errorf("invalid ksuid length %d, want %d", len(s), ksuidLength)
+	}
+	n := new(big.Int)
+	base := big.NewInt(62)
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(base62Alphabet, s[i])
+		if v < 0 {
+			return k, fmt.Errorf("invalid ksuid character %q", s[i])
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(v)))
+	}
+	if n.BitLen() > len(k)*8 {
+		return k, fmt.Errorf("invalid ksuid %q, value out of range", s)
+	}
+	n.FillBytes(k[:])
+	return k, nil
+}
+
+// IsKSUID 判断字符串是否为合法的 KSUID
+func IsKSUID(s string) bool {
+	_, err := ParseKSUID(s)
+	return err == nil
+}
+
+// KSUIDGenerator KSUID 生成器
+type KSUIDGenerator struct{}
+
+// NewKSUIDGenerator 创建 KSUID 生成器
+func NewKSUIDGenerator() *KSUIDGenerator {
+	return &KSUIDGenerator{}
+}
+
+// NextString implements the IDGenerator interface.
+func (g *KSUIDGenerator) NextString() (string, error) {
+	k, err := NewKSUID()
+	if err != nil {
+		return "", err
+	}
+	return k.String(), nil
+}
+
+// ParseTime implements the IDGenerator interface.
+func (g *KSUIDGenerator) ParseTime(id string) (time.Time, error) {
+	k, err := ParseKSUID(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return k.Time(), nil
+}
@@ -0,0 +1,53 @@
+package sequence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseKSUID(t *testing.T) {
+	k, err := ParseKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Timestamp() != 1507608047 {
+		t.Errorf("Timestamp() = %d, want %d", k.Timestamp(), 1507608047)
+	}
+	if k.String() != "0ujtsYcgvSTl8PAuAdqWYSMnLOv" {
+		t.Errorf("String() = %s", k.String())
+	}
+	tests := []struct {
+		name string
+		s    string
+		want bool
+	}{
+		{"Valid", "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true},
+		{"Min", "000000000000000000000000000", true},
+		{"Max", "aWgEPTl1tmebfsQzFP4bxwgy80V", true},
+		{"Overflow", "aWgEPTl1tmebfsQzFP4bxwgy80W", false},
+		{"Too long", "0ujtsYcgvSTl8PAuAdqWYSMnLOv0", false},
+		{"Invalid character", "0ujtsYcgvSTl8PAuAdqWYSMnLO-", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsKSUID(tt.s); got != tt.want {
+				t.Errorf("IsKSUID(%s) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKSUIDGenerator(t *testing.T) {
+	g := NewKSUIDGenerator()
+	id, err := g.NextString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := g.ParseTime(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(parsed) > time.Minute {
+		t.Errorf("ParseTime() = %v", parsed)
+	}
+}
@@ -0,0 +1,203 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minlib/go-util/filex"
+)
+
+// CounterStore 计数器的持久化存储
+type CounterStore interface {
+	// Allocate 原子地将 key 对应的值增加 step 并持久化，返回增加后的值。
+	// 返回值即为本次分配号段 (value-step, value] 的上界
+	Allocate(key string, step int64) (int64, error)
+}
+
+// MemoryCounterStore 基于内存的计数器存储，重启后重置，一般用于测试
+type MemoryCounterStore struct {
+	values map[string]int64
+	lock   sync.Mutex
+}
+
+// NewMemoryCounterStore 创建内存计数器存储
+func NewMemoryCounterStore() *MemoryCounterStore {
+	return &MemoryCounterStore{
+		values: make(map[string]int64),
+	}
+}
+
+// Allocate implements the CounterStore interface.
+func (m *MemoryCounterStore) Allocate(key string, step int64) (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.values[key] += step
+	return m.values[key], nil
+}
+
+// counterKeyPattern 文件存储允许的 key 格式，避免路径穿越
+var counterKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// FileCounterStore 基于文件的计数器存储，每个 key 对应目录下的一个文件。
+// 通过锁文件保证多进程互斥，写入临时文件后重命名保证原子性
+type FileCounterStore struct {
+	dir     string        // 存储目录
+	timeout time.Duration // 等待锁文件的超时时间
+	lock    sync.Mutex    // 锁
+}
+
+// NewFileCounterStore 创建文件计数器存储
+func NewFileCounterStore(dir string) (*FileCounterStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create counter directory %s: %w", dir, err)
+	}
+	return &FileCounterStore{
+		dir:     dir,
+		timeout: 10 * time.Second,
+	}, nil
+}
+
+// Allocate implements the CounterStore interface.
+func (f *FileCounterStore) Allocate(key string, step int64) (int64, error) {
+	if !counterKeyPattern.MatchString(key) {
+		return 0, fmt.Errorf("invalid counter key %q", key)
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	filename := filepath.Join(f.dir, key+".counter")
+	unlock, err := lockFile(filename+".lock", f.timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	var value int64
+	data, err := os.ReadFile(filename)
+	if err == nil {
+		if value, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid counter file %s: %w", filename, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read counter file %s: %w", filename, err)
+	}
+	value += step
+	if _, err := filex.WriteFileAtomic(filename, strings.NewReader(strconv.FormatInt(value, 10))); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// segment 号段 [next, max]
+type segment struct {
+	next int64 // 下一个可用值
+	max  int64 // 最大可用值
+}
+
+// SegmentCounter 号段模式的持久化计数器。每次从存储预留 step 个值，
+// 当前号段使用超过10%时在后台预取下一个号段（双缓冲），重启后从新号段继续，保证不重复。
+// 重启会丢弃未使用完的号段，因此生成的值递增但不保证连续
+type SegmentCounter struct {
+	store   CounterStore  // 持久化存储
+	key     string        // 计数器名称
+	step    int64         // 每次预留的数量
+	current *segment      // 当前号段
+	buffer  *segment      // 预取的下一个号段
+	loading chan struct{} // 正在预取时不为 nil，预取完成后关闭
+	err     error         // 最近一次预取的错误
+	lock    sync.Mutex    // 锁
+}
+
+// NewSegmentCounter 创建号段计数器
+// @store 持久化存储
+// @key 计数器名称
+// @step 每次预留的数量，如 1000
+func NewSegmentCounter(store CounterStore, key string, step int64) (*SegmentCounter, error) {
+	if store == nil {
+		return nil, errors.New("counter store can't be nil")
+	}
+	if step <= 0 {
+		return nil, errors.New("step must be greater than 0")
+	}
+	return &SegmentCounter{
+		store: store,
+		key:   key,
+		step:  step,
+	}, nil
+}
+
+// NextId 获取下一个值，已通过加锁来保证线程安全
+func (c *SegmentCounter) NextId() (int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for c.current == nil || c.current.next > c.current.max {
+		if err := c.switchSegment(); err != nil {
+			return 0, err
+		}
+	}
+	value := c.current.next
+	c.current.next++
+	// 当前号段使用超过10%时预取下一个号段
+	if c.buffer == nil && c.loading == nil && c.err == nil && (c.current.next-(c.current.max-c.step+1))*10 > c.step {
+		c.loading = make(chan struct{})
+		go c.preload(c.loading)
+	}
+	return value, nil
+}
+
+// switchSegment 切换到下一个号段，调用方需持有锁
+func (c *SegmentCounter) switchSegment() error {
+	if c.loading != nil {
+		// 等待后台预取完成
+		loading := c.loading
+		c.lock.Unlock()
+		<-loading
+		c.lock.Lock()
+	}
+	if c.current != nil && c.current.next <= c.current.max {
+		// 等待期间其他协程已切换号段
+		return nil
+	}
+	if c.buffer != nil {
+		c.current, c.buffer = c.buffer, nil
+		return nil
+	}
+	c.err = nil
+	s, err := c.allocate()
+	if err != nil {
+		return err
+	}
+	c.current = s
+	return nil
+}
+
+// preload 后台预取下一个号段
+func (c *SegmentCounter) preload(loading chan struct{}) {
+	s, err := c.allocate()
+	c.lock.Lock()
+	c.buffer, c.err = s, err
+	c.loading = nil
+	c.lock.Unlock()
+	close(loading)
+}
+
+// allocate 从存储分配一个号段
+func (c *SegmentCounter) allocate() (*segment, error) {
+	value, err := c.store.Allocate(c.key, c.step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate segment for %s: %w", c.key, err)
+	}
+	return &segment{next: value - c.step + 1, max: value}, nil
+}
+
+// Err 返回最近一次后台预取号段的错误
+func (c *SegmentCounter) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.err
+}
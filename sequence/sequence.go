@@ -1,94 +1,450 @@
 package sequence
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	// defaultStartTimestamp 默认开始时间戳 2022-01-01 00:00:00
+	defaultStartTimestamp int64 = 1640966400000
+	// minTimestampBits 时间戳至少占用的位数（约49天）
+	minTimestampBits int64 = 32
+	// maxRollbackBits 回拨位最多占用的位数，每个回拨位都需要记录最后使用的时间戳
+	maxRollbackBits int64 = 8
+)
+
+var (
+	// ErrClockMovedBackwards 发生时钟回拨且无法按策略处理
+	ErrClockMovedBackwards = errors.New("clock moved backwards")
+	// ErrTimestampOverflow 时间戳超出布局可表示的范围
+	ErrTimestampOverflow = errors.New("timestamp overflow")
+	// ErrSequenceExhausted 当前毫秒内的序列已耗尽
+	ErrSequenceExhausted = errors.New("sequence exhausted in current millisecond")
+	// ErrWorkerIdLost 机器ID已失效，如租约被其他进程接管，继续生成可能产生重复的ID
+	ErrWorkerIdLost = errors.New("worker id lost")
+)
+
+// RollbackStrategy 时钟回拨处理策略
+type RollbackStrategy int
+
+const (
+	// RollbackError 发生时钟回拨时返回错误（NextId 会 panic）
+	RollbackError RollbackStrategy = iota
+	// RollbackWait 回拨时长不超过上限时阻塞等待时钟追上，否则返回错误
+	RollbackWait
+	// RollbackBorrow 借用预留的回拨位继续生成ID，回拨位耗尽时返回错误
+	RollbackBorrow
+)
+
+// Option 配置 Sequence 的可选项
+type Option func(*Sequence)
+
+// WithEpoch 设置开始时间，默认为 2022-01-01 00:00:00
+func WithEpoch(epoch time.Time) Option {
+	return func(s *Sequence) {
+		s.startTimestamp = epoch.UnixMilli()
+	}
+}
+
+// WithDataCenterId 设置机房ID
+func WithDataCenterId(dataCenterId int64) Option {
+	return func(s *Sequence) {
+		s.dataCenterId = dataCenterId
+	}
+}
+
+// WithWorkerId 设置机器ID
+func WithWorkerId(workerId int64) Option {
+	return func(s *Sequence) {
+		s.workerId = workerId
+	}
+}
+
+// WithBits 设置机房ID、机器ID、毫秒内序列所占的位数，默认为 5/5/12
+func WithBits(dataCenterIdBits, workerIdBits, sequenceBits int64) Option {
+	return func(s *Sequence) {
+		s.dataCenterIdBits = dataCenterIdBits
+		s.workerIdBits = workerIdBits
+		s.sequenceBits = sequenceBits
+	}
+}
+
+// WithRollbackWait 发生时钟回拨时最多等待 maxWait，超过则返回错误
+func WithRollbackWait(maxWait time.Duration) Option {
+	return func(s *Sequence) {
+		s.rollbackStrategy = RollbackWait
+		s.maxRollbackWait = maxWait
+	}
+}
+
+// WithRollbackBorrow 预留 bits 位回拨位，发生时钟回拨时切换回拨位继续生成ID，bits 为 1-8
+func WithRollbackBorrow(bits int64) Option {
+	return func(s *Sequence) {
+		s.rollbackStrategy = RollbackBorrow
+		s.rollbackBits = bits
+	}
+}
+
+// WithRollbackError 发生时钟回拨时直接返回错误（默认策略）
+func WithRollbackError() Option {
+	return func(s *Sequence) {
+		s.rollbackStrategy = RollbackError
+	}
+}
+
+// WithLockFree 使用基于 CAS 的无锁实现，不支持借用回拨位
+func WithLockFree() Option {
+	return func(s *Sequence) {
+		s.lockFree = true
+	}
+}
+
 // Sequence Struct
 type Sequence struct {
-	startTimestamp     int64      // 开始时间戳
-	workerIdBits       int64      // 机器ID所占的位数
-	dataCenterIdBits   int64      // 数据标识ID所占的位数
-	maxWorkerId        int64      // 支持的最大机器ID
-	maxDataCenterId    int64      // 支持的最大机房ID
-	sequenceBits       int64      // 序列在ID中占的位数
-	workerIdShift      int64      // 机器ID向左移位数
-	dataCenterIdShift  int64      // 机房ID向左移位数
-	timestampLeftShift int64      // 时间截向左移位数
-	sequenceMask       int64      // 生成序列的掩码最大值
-	workerId           int64      // 工作机器ID
-	dataCenterId       int64      // 机房ID
-	sequence           int64      // 毫秒内序列
-	lastTimestamp      int64      // 上次生成ID的时间戳
-	lock               sync.Mutex // 锁
+	startTimestamp     int64            // 开始时间戳
+	workerIdBits       int64            // 机器ID所占的位数
+	dataCenterIdBits   int64            // 数据标识ID所占的位数
+	rollbackBits       int64            // 回拨位所占的位数
+	maxWorkerId        int64            // 支持的最大机器ID
+	maxDataCenterId    int64            // 支持的最大机房ID
+	maxTimestamp       int64            // 支持的最大相对时间戳
+	sequenceBits       int64            // 序列在ID中占的位数
+	workerIdShift      int64            // 机器ID向左移位数
+	dataCenterIdShift  int64            // 机房ID向左移位数
+	rollbackShift      int64            // 回拨位向左移位数
+	timestampLeftShift int64            // 时间截向左移位数
+	sequenceMask       int64            // 生成序列的掩码最大值
+	workerId           int64            // 工作机器ID
+	dataCenterId       int64            // 机房ID
+	sequence           int64            // 毫秒内序列
+	lastTimestamp      int64            // 上次生成ID的时间戳
+	rollbackStrategy   RollbackStrategy // 时钟回拨处理策略
+	maxRollbackWait    time.Duration    // 时钟回拨最长等待时间
+	rollback           int64            // 当前使用的回拨位
+	rollbackLast       []int64          // 每个回拨位最后使用的时间戳
+	workerIdProvider   WorkerIdProvider // 机器ID提供者
+	workerIdChecker    WorkerIdChecker  // 检查机器ID是否仍然有效
+	lockFree           bool             // 是否使用无锁实现
+	state              atomic.Int64     // 无锁实现的状态：相对时间戳与毫秒内序列
+	now                func() int64     // 获取当前毫秒时间戳
+	lock               sync.Mutex       // 锁
 }
 
 // New 创建一个实例化对象
 func New(dataCenterId int64, workerId int64) *Sequence {
-	var s = Sequence{}
-	// 开始时间戳，默认为 2022-01-01 00:00:00
-	s.startTimestamp = 1640966400000
-	// 机器ID所占的位数
-	s.workerIdBits = 5
-	// 数据标识ID所占的位数
-	s.dataCenterIdBits = 5
-	// 支持的最大机器ID，最大是31
+	s, err := NewWithOptions(WithDataCenterId(dataCenterId), WithWorkerId(workerId))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewWithOptions 根据配置项创建一个实例化对象
+func NewWithOptions(opts ...Option) (*Sequence, error) {
+	var s = Sequence{
+		startTimestamp:   defaultStartTimestamp,
+		workerIdBits:     5,
+		dataCenterIdBits: 5,
+		sequenceBits:     12,
+		rollbackStrategy: RollbackError,
+		now: func() int64 {
+			return time.Now().UnixMilli()
+		},
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.workerIdBits < 0 || s.dataCenterIdBits < 0 || s.rollbackBits < 0 {
+		return nil, errors.New("bits can't be less than 0")
+	}
+	if s.sequenceBits < 1 {
+		return nil, errors.New("sequenceBits can't be less than 1")
+	}
+	timestampBits := 63 - s.sequenceBits - s.workerIdBits - s.dataCenterIdBits - s.rollbackBits
+	if timestampBits < minTimestampBits {
+		return nil, fmt.Errorf("too many bits for layout, timestamp needs at least %d bits", minTimestampBits)
+	}
+	if s.rollbackStrategy == RollbackBorrow && s.rollbackBits == 0 {
+		return nil, errors.New("rollbackBits can't be 0 when borrowing rollback bits")
+	}
+	if s.rollbackBits > maxRollbackBits {
+		return nil, fmt.Errorf("rollbackBits can't be greater than %d", maxRollbackBits)
+	}
+	if s.rollbackStrategy == RollbackBorrow && s.lockFree {
+		return nil, errors.New("borrowing rollback bits is not supported by the lock-free implementation")
+	}
+	if s.rollbackStrategy == RollbackWait && s.maxRollbackWait <= 0 {
+		return nil, errors.New("maxRollbackWait must be greater than 0")
+	}
+	if s.startTimestamp > s.now() {
+		return nil, errors.New("epoch can't be in the future")
+	}
+	// 支持的最大机器ID
 	s.maxWorkerId = -1 ^ (-1 << s.workerIdBits)
-	// 支持的最大机房ID，最大是 31
+	// 支持的最大机房ID
 	s.maxDataCenterId = -1 ^ (-1 << s.dataCenterIdBits)
-	// 序列在ID中占的位数
-	s.sequenceBits = 12
-	// 机器ID向左移12位
+	// 支持的最大相对时间戳
+	s.maxTimestamp = -1 ^ (-1 << timestampBits)
+	// 机器ID向左移位数
 	s.workerIdShift = s.sequenceBits
-	// 机房ID向左移17位
+	// 机房ID向左移位数
 	s.dataCenterIdShift = s.sequenceBits + s.workerIdBits
-	// 时间截向左移22位
-	s.timestampLeftShift = s.sequenceBits + s.workerIdBits + s.dataCenterIdBits
-	// 生成序列的掩码最大值，最大为4095
+	// 回拨位向左移位数
+	s.rollbackShift = s.dataCenterIdShift + s.dataCenterIdBits
+	// 时间截向左移位数
+	s.timestampLeftShift = s.rollbackShift + s.rollbackBits
+	// 生成序列的掩码最大值
 	s.sequenceMask = -1 ^ (-1 << s.sequenceBits)
-	if workerId < 0 || workerId > s.maxWorkerId {
-		panic(fmt.Errorf("workerId can't be greater than %d or less than 0", s.maxWorkerId))
+	if s.workerIdProvider != nil {
+		// 机房ID与机器ID合并分配，高位为机房ID
+		id, err := s.workerIdProvider.WorkerId(-1 ^ (-1 << (s.dataCenterIdBits + s.workerIdBits)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get worker id: %w", err)
+		}
+		s.dataCenterId = id >> s.workerIdBits
+		s.workerId = id & s.maxWorkerId
+		s.workerIdChecker, _ = s.workerIdProvider.(WorkerIdChecker)
+	}
+	if s.workerId < 0 || s.workerId > s.maxWorkerId {
+		return nil, fmt.Errorf("workerId can't be greater than %d or less than 0", s.maxWorkerId)
+	}
+	if s.dataCenterId < 0 || s.dataCenterId > s.maxDataCenterId {
+		return nil, fmt.Errorf("dataCenterId can't be greater than %d or less than 0", s.maxDataCenterId)
 	}
-	if dataCenterId < 0 || dataCenterId > s.maxDataCenterId {
-		panic(fmt.Errorf("dataCenterId can't be greater than %d or less than 0", s.maxDataCenterId))
+	s.rollbackLast = make([]int64, 1<<s.rollbackBits)
+	for i := range s.rollbackLast {
+		s.rollbackLast[i] = -1
 	}
-	s.workerId = workerId
-	s.dataCenterId = dataCenterId
-	// 毫秒内序列(0~4095)
+	// 毫秒内序列
 	s.sequence = 0
 	// 上次生成 ID 的时间戳
 	s.lastTimestamp = -1
-	return &s
+	s.state.Store(-1)
+	return &s, nil
 }
 
-// NextId 生成ID，注意此方法已经通过加锁来保证线程安全
+// NextId 生成ID，注意此方法已经通过加锁来保证线程安全。无法生成时（如时钟回拨）会 panic，
+// 不希望 panic 时请使用 Generate
 func (s *Sequence) NextId() int64 {
+	id, err := s.Generate()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Generate 生成ID，无法生成时返回错误，已通过加锁来保证线程安全
+func (s *Sequence) Generate() (int64, error) {
+	if err := s.checkWorkerId(); err != nil {
+		return 0, err
+	}
+	if s.lockFree {
+		timestamp, sequence, _, err := s.reserveLockFree(1, true)
+		if err != nil {
+			return 0, err
+		}
+		return s.compose(timestamp, 0, sequence), nil
+	}
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	timestamp := time.Now().UnixMilli()
-	// 如果当前时间小于上一次 ID 生成的时间戳，说明发生时钟回拨，为保证ID不重复抛出异常。
+	timestamp, sequence, _, err := s.reserve(1, true)
+	if err != nil {
+		return 0, err
+	}
+	return s.compose(timestamp, s.rollback, sequence), nil
+}
+
+// TryNextId 生成ID，毫秒内序列耗尽或需要等待时钟回拨时立即返回错误，不会阻塞
+func (s *Sequence) TryNextId() (int64, error) {
+	if err := s.checkWorkerId(); err != nil {
+		return 0, err
+	}
+	if s.lockFree {
+		timestamp, sequence, _, err := s.reserveLockFree(1, false)
+		if err != nil {
+			return 0, err
+		}
+		return s.compose(timestamp, 0, sequence), nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	timestamp, sequence, _, err := s.reserve(1, false)
+	if err != nil {
+		return 0, err
+	}
+	return s.compose(timestamp, s.rollback, sequence), nil
+}
+
+// NextIds 批量生成 n 个ID，同一毫秒内的ID为连续的一段，只加一次锁
+func (s *Sequence) NextIds(n int) ([]int64, error) {
+	if n <= 0 {
+		return []int64{}, nil
+	}
+	if err := s.checkWorkerId(); err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, n)
+	if !s.lockFree {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+	}
+	for len(ids) < n {
+		var timestamp, sequence, count, rollback int64
+		var err error
+		if s.lockFree {
+			timestamp, sequence, count, err = s.reserveLockFree(int64(n-len(ids)), true)
+		} else {
+			timestamp, sequence, count, err = s.reserve(int64(n-len(ids)), true)
+			rollback = s.rollback
+		}
+		if err != nil {
+			return nil, err
+		}
+		first := s.compose(timestamp, rollback, sequence)
+		for i := int64(0); i < count; i++ {
+			ids = append(ids, first+i)
+		}
+	}
+	return ids, nil
+}
+
+// checkWorkerId 机器ID提供者报告机器ID失效时返回 ErrWorkerIdLost
+func (s *Sequence) checkWorkerId() error {
+	if s.workerIdChecker == nil {
+		return nil
+	}
+	if err := s.workerIdChecker.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrWorkerIdLost, err)
+	}
+	return nil
+}
+
+// compose 移位并通过或运算拼到一起
+func (s *Sequence) compose(timestamp, rollback, sequence int64) int64 {
+	return ((timestamp - s.startTimestamp) << s.timestampLeftShift) | (rollback << s.rollbackShift) | (s.dataCenterId << s.dataCenterIdShift) | (s.workerId << s.workerIdShift) | sequence
+}
+
+// reserve 在当前毫秒内预留最多 n 个序列，返回时间戳、起始序列与实际预留数量，调用方需持有锁
+func (s *Sequence) reserve(n int64, block bool) (timestamp, sequence, count int64, err error) {
+	timestamp = s.now()
 	if timestamp < s.lastTimestamp {
-		panic(fmt.Errorf("clock moved backwards. Refusing to generate id for %d milliseconds", s.lastTimestamp-timestamp))
+		if timestamp, err = s.handleRollback(timestamp, block); err != nil {
+			return 0, 0, 0, err
+		}
 	}
 	if s.lastTimestamp == timestamp {
 		// 同一时间生成的，则序号+1
-		s.sequence = (s.sequence + 1) & s.sequenceMask
+		sequence = s.sequence + 1
 		// 毫秒内序列溢出：超过最大值
-		if s.sequence == 0 {
-			// 阻塞到下一个毫秒，获得新的时间戳
-			timestamp = time.Now().UnixMilli()
-			for timestamp <= s.lastTimestamp {
-				timestamp = time.Now().UnixMilli()
+		if sequence > s.sequenceMask {
+			if !block {
+				return 0, 0, 0, ErrSequenceExhausted
 			}
+			// 阻塞到下一个毫秒，获得新的时间戳
+			timestamp = s.tilNextMillis(s.lastTimestamp)
+			sequence = 0
 		}
 	} else {
 		// 时间戳改变，序列重置
-		s.sequence = 0
+		sequence = 0
 	}
-	// 保存本次的时间戳
+	if timestamp-s.startTimestamp > s.maxTimestamp {
+		return 0, 0, 0, fmt.Errorf("%w: %d exceeds %d milliseconds since epoch", ErrTimestampOverflow, timestamp-s.startTimestamp, s.maxTimestamp)
+	}
+	count = min(n, s.sequenceMask-sequence+1)
+	// 保存本次的时间戳与序列
+	s.sequence = sequence + count - 1
 	s.lastTimestamp = timestamp
-	// 移位并通过或运算拼到一起
-	return ((timestamp - s.startTimestamp) << s.timestampLeftShift) | (s.dataCenterId << s.dataCenterIdShift) | (s.workerId << s.workerIdShift) | s.sequence
+	s.rollbackLast[s.rollback] = timestamp
+	return timestamp, sequence, count, nil
+}
+
+// handleRollback 按策略处理时钟回拨，返回可用的时间戳
+func (s *Sequence) handleRollback(timestamp int64, block bool) (int64, error) {
+	offset := s.lastTimestamp - timestamp
+	switch s.rollbackStrategy {
+	case RollbackWait:
+		if !block {
+			return 0, fmt.Errorf("%w: need to wait %d milliseconds", ErrClockMovedBackwards, offset)
+		}
+		return s.waitRollback(timestamp, s.lastTimestamp)
+	case RollbackBorrow:
+		// 选择一个在当前时间戳之后从未使用过的回拨位，保证ID不重复
+		for i := range s.rollbackLast {
+			if s.rollbackLast[i] < timestamp {
+				s.rollback = int64(i)
+				s.lastTimestamp = s.rollbackLast[i]
+				return timestamp, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: all %d rollback slots are in use for %d milliseconds", ErrClockMovedBackwards, len(s.rollbackLast), offset)
+	default:
+		return 0, fmt.Errorf("%w: refusing to generate id for %d milliseconds", ErrClockMovedBackwards, offset)
+	}
+}
+
+// waitRollback 等待时钟追上 lastTimestamp，超过等待上限时返回错误
+func (s *Sequence) waitRollback(timestamp, lastTimestamp int64) (int64, error) {
+	offset := lastTimestamp - timestamp
+	if time.Duration(offset)*time.Millisecond > s.maxRollbackWait {
+		return 0, fmt.Errorf("%w: refusing to wait %d milliseconds", ErrClockMovedBackwards, offset)
+	}
+	deadline := time.Now().Add(s.maxRollbackWait)
+	for timestamp < lastTimestamp {
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("%w: clock did not catch up within %s", ErrClockMovedBackwards, s.maxRollbackWait)
+		}
+		time.Sleep(time.Duration(lastTimestamp-timestamp) * time.Millisecond)
+		timestamp = s.now()
+	}
+	return timestamp, nil
+}
+
+// tilNextMillis 阻塞到下一个毫秒，直到获得新的时间戳。通过休眠让出CPU，避免空转
+func (s *Sequence) tilNextMillis(lastTimestamp int64) int64 {
+	timestamp := s.now()
+	for timestamp <= lastTimestamp {
+		time.Sleep(time.Until(time.UnixMilli(lastTimestamp + 1)))
+		timestamp = s.now()
+	}
+	return timestamp
+}
+
+// Parts 解析后的ID组成部分
+type Parts struct {
+	Id           int64     `json:"id"`           // 原始ID
+	Time         time.Time `json:"time"`         // 生成时间
+	Timestamp    int64     `json:"timestamp"`    // 生成时的毫秒时间戳
+	Rollback     int64     `json:"rollback"`     // 回拨位
+	DataCenterId int64     `json:"dataCenterId"` // 机房ID
+	WorkerId     int64     `json:"workerId"`     // 机器ID
+	Sequence     int64     `json:"sequence"`     // 毫秒内序列
+}
+
+// Decompose 按当前实例的布局将ID解析为时间、机房ID、机器ID与毫秒内序列
+func (s *Sequence) Decompose(id int64) Parts {
+	timestamp := (id >> s.timestampLeftShift) + s.startTimestamp
+	return Parts{
+		Id:           id,
+		Time:         time.UnixMilli(timestamp),
+		Timestamp:    timestamp,
+		Rollback:     (id >> s.rollbackShift) & (-1 ^ (-1 << s.rollbackBits)),
+		DataCenterId: (id >> s.dataCenterIdShift) & s.maxDataCenterId,
+		WorkerId:     (id >> s.workerIdShift) & s.maxWorkerId,
+		Sequence:     id & s.sequenceMask,
+	}
+}
+
+// Time 解析ID的生成时间
+func (s *Sequence) Time(id int64) time.Time {
+	return time.UnixMilli((id >> s.timestampLeftShift) + s.startTimestamp)
+}
+
+func (p Parts) String() string {
+	return fmt.Sprintf("id=%d time=%s dataCenterId=%d workerId=%d sequence=%d rollback=%d",
+		p.Id, p.Time.Format("2006-01-02 15:04:05.000"), p.DataCenterId, p.WorkerId, p.Sequence, p.Rollback)
 }
@@ -0,0 +1,47 @@
+package sequence
+
+import (
+	"fmt"
+	"time"
+)
+
+// reserveLockFree 基于 CAS 在当前毫秒内预留最多 n 个序列，返回时间戳、起始序列与实际预留数量。
+// 状态字高位为相对开始时间戳的毫秒数，低 sequenceBits 位为已使用的最大序列，初始为 -1
+func (s *Sequence) reserveLockFree(n int64, block bool) (timestamp, sequence, count int64, err error) {
+	for {
+		state := s.state.Load()
+		lastTimestamp := state>>s.sequenceBits + s.startTimestamp
+		lastSequence := state & s.sequenceMask
+		timestamp = s.now()
+		if timestamp < lastTimestamp {
+			if s.rollbackStrategy != RollbackWait || !block {
+				return 0, 0, 0, fmt.Errorf("%w: refusing to generate id for %d milliseconds", ErrClockMovedBackwards, lastTimestamp-timestamp)
+			}
+			if _, err = s.waitRollback(timestamp, lastTimestamp); err != nil {
+				return 0, 0, 0, err
+			}
+			continue
+		}
+		if timestamp == lastTimestamp {
+			if lastSequence == s.sequenceMask {
+				if !block {
+					return 0, 0, 0, ErrSequenceExhausted
+				}
+				// 休眠到下一个毫秒后重试
+				time.Sleep(time.Until(time.UnixMilli(lastTimestamp + 1)))
+				continue
+			}
+			sequence = lastSequence + 1
+		} else {
+			sequence = 0
+		}
+		if timestamp-s.startTimestamp > s.maxTimestamp {
+			return 0, 0, 0, fmt.Errorf("%w: %d exceeds %d milliseconds since epoch", ErrTimestampOverflow, timestamp-s.startTimestamp, s.maxTimestamp)
+		}
+		count = min(n, s.sequenceMask-sequence+1)
+		next := (timestamp-s.startTimestamp)<<s.sequenceBits | (sequence + count - 1)
+		if s.state.CompareAndSwap(state, next) {
+			return timestamp, sequence, count, nil
+		}
+	}
+}
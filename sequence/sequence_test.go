@@ -1,8 +1,11 @@
 package sequence
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestSequence(t *testing.T) {
@@ -13,3 +16,266 @@ func TestSequence(t *testing.T) {
 	sequence2 := New(1, 1)
 	fmt.Println(sequence2.NextId())
 }
+
+// fakeClock 可手动调整的时钟，用于模拟时钟回拨
+type fakeClock struct {
+	millis int64
+}
+
+func (c *fakeClock) now() int64 {
+	return c.millis
+}
+
+func newWithClock(t *testing.T, clock *fakeClock, opts ...Option) *Sequence {
+	s, err := NewWithOptions(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = clock.now
+	return s
+}
+
+func TestNewWithOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{"Default", nil, false},
+		{"Custom bits", []Option{WithBits(3, 7, 10), WithWorkerId(100)}, false},
+		{"Too many bits", []Option{WithBits(10, 10, 12)}, true},
+		{"Zero sequence bits", []Option{WithBits(5, 5, 0)}, true},
+		{"WorkerId out of range", []Option{WithWorkerId(32)}, true},
+		{"DataCenterId out of range", []Option{WithDataCenterId(-1)}, true},
+		{"Future epoch", []Option{WithEpoch(time.Now().Add(time.Hour))}, true},
+		{"Borrow without bits", []Option{WithRollbackBorrow(0)}, true},
+		{"Borrow max bits", []Option{WithRollbackBorrow(8)}, false},
+		{"Borrow too many bits", []Option{WithBits(0, 0, 1), WithRollbackBorrow(30)}, true},
+		{"Wait without bound", []Option{WithRollbackWait(0)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWithOptions(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSequenceLayout(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{millis: epoch.UnixMilli() + 1000}
+	s := newWithClock(t, clock, WithEpoch(epoch), WithBits(3, 7, 10), WithDataCenterId(5), WithWorkerId(100))
+	id := s.NextId()
+	want := int64(1000)<<20 | 5<<17 | 100<<10
+	if id != want {
+		t.Errorf("NextId() = %d, want %d", id, want)
+	}
+	if next := s.NextId(); next != want+1 {
+		t.Errorf("NextId() = %d, want %d", next, want+1)
+	}
+}
+
+func TestSequenceRollbackError(t *testing.T) {
+	clock := &fakeClock{millis: time.Now().UnixMilli()}
+	s := newWithClock(t, clock)
+	if _, err := s.Generate(); err != nil {
+		t.Fatal(err)
+	}
+	clock.millis -= 10
+	if _, err := s.Generate(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrClockMovedBackwards)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NextId() should panic when clock moved backwards")
+		}
+	}()
+	s.NextId()
+}
+
+func TestSequenceRollbackWait(t *testing.T) {
+	s, err := NewWithOptions(WithRollbackWait(50 * time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := s.NextId()
+	// 模拟时钟回拨 20 毫秒，等待时钟追上后继续生成
+	s.lastTimestamp += 20
+	second, err := s.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second <= first {
+		t.Errorf("Generate() = %d, want greater than %d", second, first)
+	}
+	// 回拨超过等待上限时返回错误
+	s.lastTimestamp += 1000
+	if _, err := s.Generate(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrClockMovedBackwards)
+	}
+}
+
+func TestSequenceRollbackBorrow(t *testing.T) {
+	clock := &fakeClock{millis: time.Now().UnixMilli()}
+	s := newWithClock(t, clock, WithRollbackBorrow(1))
+	ids := make(map[int64]struct{})
+	generate := func() error {
+		id, err := s.Generate()
+		if err != nil {
+			return err
+		}
+		if _, found := ids[id]; found {
+			t.Fatalf("duplicate id %d", id)
+		}
+		ids[id] = struct{}{}
+		return nil
+	}
+	for i := 0; i < 10; i++ {
+		if err := generate(); err != nil {
+			t.Fatal(err)
+		}
+		clock.millis++
+	}
+	// 第一次回拨借用回拨位
+	clock.millis -= 5
+	for i := 0; i < 3; i++ {
+		if err := generate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 第二次回拨时回拨位已耗尽
+	clock.millis -= 2
+	if err := generate(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrClockMovedBackwards)
+	}
+}
+
+func TestSequenceDecompose(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{millis: epoch.UnixMilli() + 123456}
+	s := newWithClock(t, clock, WithEpoch(epoch), WithBits(4, 6, 10), WithDataCenterId(9), WithWorkerId(42), WithRollbackBorrow(2))
+	s.NextId()
+	id := s.NextId()
+	parts := s.Decompose(id)
+	if parts.Timestamp != clock.millis || !parts.Time.Equal(time.UnixMilli(clock.millis)) {
+		t.Errorf("Decompose() time = %v, want %v", parts.Time, time.UnixMilli(clock.millis))
+	}
+	if parts.DataCenterId != 9 || parts.WorkerId != 42 || parts.Sequence != 1 || parts.Rollback != 0 {
+		t.Errorf("Decompose() = %+v", parts)
+	}
+	clock.millis -= 10
+	parts = s.Decompose(s.NextId())
+	if parts.Rollback != 1 || parts.Sequence != 0 || parts.WorkerId != 42 {
+		t.Errorf("Decompose() = %+v", parts)
+	}
+	if !s.Time(id).Equal(time.UnixMilli(clock.millis + 10)) {
+		t.Errorf("Time() = %v", s.Time(id))
+	}
+}
+
+func TestSequenceNextIds(t *testing.T) {
+	for _, lockFree := range []bool{false, true} {
+		var opts []Option
+		if lockFree {
+			opts = append(opts, WithLockFree())
+		}
+		s, err := NewWithOptions(opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids, err := s.NextIds(10000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 10000 {
+			t.Fatalf("NextIds() len = %d, want %d", len(ids), 10000)
+		}
+		for i := 1; i < len(ids); i++ {
+			if ids[i] <= ids[i-1] {
+				t.Fatalf("NextIds() not increasing at %d: %d <= %d", i, ids[i], ids[i-1])
+			}
+		}
+		if next := s.NextId(); next <= ids[len(ids)-1] {
+			t.Errorf("NextId() = %d, want greater than %d", next, ids[len(ids)-1])
+		}
+	}
+}
+
+func TestSequenceTryNextId(t *testing.T) {
+	for _, lockFree := range []bool{false, true} {
+		clock := &fakeClock{millis: time.Now().UnixMilli()}
+		opts := []Option{WithBits(5, 5, 2)}
+		if lockFree {
+			opts = append(opts, WithLockFree())
+		}
+		s := newWithClock(t, clock, opts...)
+		for i := 0; i < 4; i++ {
+			if _, err := s.TryNextId(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := s.TryNextId(); !errors.Is(err, ErrSequenceExhausted) {
+			t.Errorf("TryNextId() error = %v, want %v", err, ErrSequenceExhausted)
+		}
+		clock.millis++
+		if _, err := s.TryNextId(); err != nil {
+			t.Errorf("TryNextId() error = %v", err)
+		}
+	}
+}
+
+func TestSequenceLockFreeConcurrent(t *testing.T) {
+	s, err := NewWithOptions(WithLockFree())
+	if err != nil {
+		t.Fatal(err)
+	}
+	const workers, count = 8, 20000
+	results := make([][]int64, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				results[w] = append(results[w], s.NextId())
+			}
+		}(w)
+	}
+	wg.Wait()
+	ids := make(map[int64]struct{}, workers*count)
+	for _, result := range results {
+		for _, id := range result {
+			if _, found := ids[id]; found {
+				t.Fatalf("duplicate id %d", id)
+			}
+			ids[id] = struct{}{}
+		}
+	}
+}
+
+func BenchmarkSequence_NextId(b *testing.B) {
+	s := New(0, 1)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.NextId()
+		}
+	})
+}
+
+func BenchmarkSequence_NextIdLockFree(b *testing.B) {
+	s, _ := NewWithOptions(WithLockFree())
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.NextId()
+		}
+	})
+}
+
+func BenchmarkSequence_NextIds(b *testing.B) {
+	s := New(0, 1)
+	for i := 0; i < b.N; i++ {
+		_, _ = s.NextIds(1000)
+	}
+}
@@ -0,0 +1,314 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minlib/go-util/random"
+	"github.com/minlib/go-util/stringx"
+)
+
+// ResetPeriod 流水号序列的重置周期
+type ResetPeriod int
+
+const (
+	// ResetAuto 根据模式中最小的日期单位自动确定重置周期（默认）
+	ResetAuto ResetPeriod = iota
+	// ResetNever 从不重置
+	ResetNever
+	// ResetYearly 每年重置
+	ResetYearly
+	// ResetMonthly 每月重置
+	ResetMonthly
+	// ResetDaily 每天重置
+	ResetDaily
+)
+
+// serialDateTokens 日期占位符与 Go 时间格式的对应关系，按长度优先匹配
+var serialDateTokens = []struct {
+	token  string
+	layout string
+	period ResetPeriod
+}{
+	{"yyyy", "2006", ResetYearly},
+	{"yy", "06", ResetYearly},
+	{"MM", "01", ResetMonthly},
+	{"dd", "02", ResetDaily},
+	{"HH", "15", ResetDaily},
+	{"mm", "04", ResetDaily},
+	{"ss", "05", ResetDaily},
+}
+
+// serialSegmentKind 流水号模式的片段类型
+type serialSegmentKind int
+
+const (
+	serialLiteral serialSegmentKind = iota // 固定文本
+	serialDate                             // 日期
+	serialSeq                              // 序列
+	serialRand                             // 随机数字
+	serialCheck                            // 校验位
+)
+
+// serialSegment 流水号模式的片段
+type serialSegment struct {
+	kind   serialSegmentKind
+	text   string      // 固定文本或日期格式
+	width  int         // 序列或随机数字的位数
+	period ResetPeriod // 日期格式中最小日期单位对应的重置周期
+}
+
+// SerialOption 配置 Serial 的可选项
+type SerialOption func(*Serial)
+
+// WithSerialReset 设置序列的重置周期
+func WithSerialReset(period ResetPeriod) SerialOption {
+	return func(g *Serial) {
+		g.reset = period
+	}
+}
+
+// WithSerialStore 使用持久化存储保存序列，重启后不重复
+// @store 持久化存储
+// @key 计数器名称，实际存储的名称会附加周期，如 order.20261018
+// @step 每次预留的号段数量
+func WithSerialStore(store CounterStore, key string, step int64) SerialOption {
+	return func(g *Serial) {
+		g.store = store
+		g.key = key
+		g.step = step
+	}
+}
+
+// Serial 按模式生成业务流水号，如 "ORD{yyyyMMdd}{seq:6}" 生成 ORD20261018000123。
+// 支持的占位符：
+//   - {yyyyMMdd} 等日期格式，可使用 yyyy、yy、MM、dd、HH、mm、ss
+//   - {seq:N} 序列，不足 N 位前面补零，超过 N 位时按实际长度输出
+//   - {rand:N} N 位随机数字
+//   - {check} 校验位，对前面已生成的内容计算 Luhn 校验位，字母按36进制转为数字
+//
+// 已通过加锁来保证线程安全
+type Serial struct {
+	segments []serialSegment  // 模式片段
+	reset    ResetPeriod      // 重置周期
+	store    CounterStore     // 持久化存储，为 nil 时使用内存计数器
+	key      string           // 持久化计数器名称
+	step     int64            // 持久化号段数量
+	period   string           // 持久化计数器的当前周期
+	counters map[string]int64 // 各周期的内存序列值
+	segment  *SegmentCounter  // 持久化计数器
+	now      func() time.Time // 获取当前时间
+	lock     sync.Mutex       // 锁
+}
+
+// NewSerial 根据模式创建流水号生成器
+func NewSerial(pattern string, opts ...SerialOption) (*Serial, error) {
+	segments, err := parseSerialPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	g := &Serial{
+		segments: segments,
+		reset:    ResetAuto,
+		counters: make(map[string]int64),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.reset == ResetAuto {
+		g.reset = detectResetPeriod(segments)
+	}
+	if g.store != nil {
+		if g.step <= 0 {
+			return nil, errors.New("step must be greater than 0")
+		}
+		if !counterKeyPattern.MatchString(g.key) {
+			return nil, fmt.Errorf("invalid counter key %q", g.key)
+		}
+	}
+	return g, nil
+}
+
+// parseSerialPattern 解析流水号模式
+func parseSerialPattern(pattern string) ([]serialSegment, error) {
+	var segments []serialSegment
+	var hasSeq bool
+	for pattern != "" {
+		start := strings.IndexByte(pattern, '{')
+		if start < 0 {
+			segments = append(segments, serialSegment{kind: serialLiteral, text: pattern})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, serialSegment{kind: serialLiteral, text: pattern[:start]})
+		}
+		end := strings.IndexByte(pattern[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed placeholder in pattern %q", pattern)
+		}
+		placeholder := pattern[start+1 : start+end]
+		pattern = pattern[start+end+1:]
+		name, arg, _ := strings.Cut(placeholder, ":")
+		switch name {
+		case "seq", "rand":
+			width, err := strconv.Atoi(arg)
+			if err != nil || width <= 0 {
+				return nil, fmt.Errorf("invalid width in placeholder {%s}", placeholder)
+			}
+			kind := serialRand
+			if name == "seq" {
+				kind = serialSeq
+				hasSeq = true
+			}
+			segments = append(segments, serialSegment{kind: kind, width: width})
+		case "check":
+			segments = append(segments, serialSegment{kind: serialCheck})
+		default:
+			layout, period, err := serialDateLayout(placeholder)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, serialSegment{kind: serialDate, text: layout, period: period})
+		}
+	}
+	if !hasSeq {
+		return nil, errors.New("pattern must contain a {seq:N} placeholder")
+	}
+	return segments, nil
+}
+
+// serialDateLayout 将日期占位符转换为 Go 时间格式，同时返回最小日期单位对应的重置周期
+func serialDateLayout(placeholder string) (string, ResetPeriod, error) {
+	var layout strings.Builder
+	period := ResetNever
+	for rest := placeholder; rest != ""; {
+		matched := false
+		for _, t := range serialDateTokens {
+			if strings.HasPrefix(rest, t.token) {
+				layout.WriteString(t.layout)
+				period = max(period, t.period)
+				rest = rest[len(t.token):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", 0, fmt.Errorf("unknown placeholder {%s}", placeholder)
+		}
+	}
+	return layout.String(), period, nil
+}
+
+// detectResetPeriod 根据所有日期格式中最小的日期单位确定重置周期
+func detectResetPeriod(segments []serialSegment) ResetPeriod {
+	period := ResetNever
+	for _, segment := range segments {
+		if segment.kind == serialDate {
+			period = max(period, segment.period)
+		}
+	}
+	return period
+}
+
+// periodOf 返回时间所在的周期
+func (g *Serial) periodOf(t time.Time) string {
+	switch g.reset {
+	case ResetDaily:
+		return t.Format("20060102")
+	case ResetMonthly:
+		return t.Format("200601")
+	case ResetYearly:
+		return t.Format("2006")
+	default:
+		return ""
+	}
+}
+
+// Next 生成下一个流水号
+func (g *Serial) Next() (string, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	now := g.now()
+	value, err := g.nextValue(g.periodOf(now))
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	for _, segment := range g.segments {
+		switch segment.kind {
+		case serialLiteral:
+			builder.WriteString(segment.text)
+		case serialDate:
+			builder.WriteString(now.Format(segment.text))
+		case serialSeq:
+			builder.WriteString(stringx.ZeroFill(int(value), segment.width))
+		case serialRand:
+			builder.WriteString(random.Numeric(segment.width))
+		case serialCheck:
+			builder.WriteByte(luhnDigit(builder.String()))
+		}
+	}
+	return builder.String(), nil
+}
+
+// nextValue 获取当前周期的下一个序列值，每个周期单独计数，时钟回拨到之前的周期时继续该周期的序列而不会重复，
+// 调用方需持有锁
+func (g *Serial) nextValue(period string) (int64, error) {
+	if g.store == nil {
+		g.counters[period]++
+		return g.counters[period], nil
+	}
+	if g.segment == nil || period != g.period {
+		key := g.key
+		if period != "" {
+			key += "." + period
+		}
+		segment, err := NewSegmentCounter(g.store, key, g.step)
+		if err != nil {
+			return 0, err
+		}
+		g.period = period
+		g.segment = segment
+	}
+	return g.segment.NextId()
+}
+
+// VerifyCheckDigit 校验以 {check} 结尾的流水号，最后一位为前面内容的 Luhn 校验位
+func VerifyCheckDigit(serial string) bool {
+	if len(serial) < 2 {
+		return false
+	}
+	return luhnDigit(serial[:len(serial)-1]) == serial[len(serial)-1]
+}
+
+// luhnDigit 计算 Luhn 校验位，字母按36进制（A=10...Z=35）展开为数字，其他字符忽略
+func luhnDigit(s string) byte {
+	var digits []int
+	for _, c := range strings.ToUpper(s) {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, int(c-'0'))
+		case c >= 'A' && c <= 'Z':
+			v := int(c-'A') + 10
+			digits = append(digits, v/10, v%10)
+		}
+	}
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		// 从右向左，校验位左侧第一位开始每隔一位乘2
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
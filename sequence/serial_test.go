@@ -0,0 +1,142 @@
+package sequence
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSerial(t *testing.T) {
+	g, err := NewSerial("ORD{yyyyMMdd}{seq:6}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.Local)
+	g.now = func() time.Time { return now }
+	for i := 1; i <= 3; i++ {
+		got, _ := g.Next()
+		want := fmt.Sprintf("ORD20261018%06d", i)
+		if got != want {
+			t.Errorf("Next() = %s, want %s", got, want)
+		}
+	}
+	// 跨天后序列重置
+	now = now.AddDate(0, 0, 1)
+	if got, _ := g.Next(); got != "ORD20261019000001" {
+		t.Errorf("Next() = %s, want %s", got, "ORD20261019000001")
+	}
+}
+
+func TestSerialClockBackwards(t *testing.T) {
+	g, err := NewSerial("ORD{yyyyMMdd}{seq:6}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 18, 23, 59, 59, 0, time.Local)
+	g.now = func() time.Time { return now }
+	steps := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{0, "ORD20261018000001"},
+		{0, "ORD20261018000002"},
+		{2 * time.Second, "ORD20261019000001"},
+		// 时钟回拨到前一天，继续前一天的序列
+		{-2 * time.Second, "ORD20261018000003"},
+		{2 * time.Second, "ORD20261019000002"},
+	}
+	for _, step := range steps {
+		now = now.Add(step.offset)
+		if got, _ := g.Next(); got != step.want {
+			t.Errorf("Next() at %s = %s, want %s", now.Format(time.DateTime), got, step.want)
+		}
+	}
+}
+
+func TestSerialReset(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    []SerialOption
+		want    ResetPeriod
+	}{
+		{"{yyyyMMdd}{seq:4}", nil, ResetDaily},
+		{"{yyMM}{seq:4}", nil, ResetMonthly},
+		{"{yyyy}-{seq:4}", nil, ResetYearly},
+		{"NO{seq:8}", nil, ResetNever},
+		{"{yyyyMMdd}{seq:4}", []SerialOption{WithSerialReset(ResetMonthly)}, ResetMonthly},
+	}
+	for _, tt := range tests {
+		g, err := NewSerial(tt.pattern, tt.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.reset != tt.want {
+			t.Errorf("NewSerial(%s) reset = %v, want %v", tt.pattern, g.reset, tt.want)
+		}
+	}
+}
+
+func TestSerialPatternError(t *testing.T) {
+	for _, pattern := range []string{"ORD{yyyyMMdd}", "ORD{seq:0}", "ORD{seq:6", "ORD{abc}{seq:6}"} {
+		if _, err := NewSerial(pattern); err == nil {
+			t.Errorf("NewSerial(%s) should fail", pattern)
+		}
+	}
+}
+
+func TestSerialRandAndCheck(t *testing.T) {
+	g, err := NewSerial("INV{yyMMdd}{seq:4}{rand:3}{check}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		serial, _ := g.Next()
+		if len(serial) != 17 {
+			t.Fatalf("Next() = %s, want length 17", serial)
+		}
+		if !VerifyCheckDigit(serial) {
+			t.Fatalf("VerifyCheckDigit(%s) = false", serial)
+		}
+	}
+	if !VerifyCheckDigit("79927398713") || VerifyCheckDigit("79927398710") {
+		t.Errorf("VerifyCheckDigit() is incorrect for luhn test number")
+	}
+}
+
+func TestSerialStore(t *testing.T) {
+	store, err := NewFileCounterStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.Local)
+	g1, _ := NewSerial("ORD{yyyyMMdd}{seq:6}", WithSerialStore(store, "order", 10))
+	g1.now = func() time.Time { return now }
+	first, _ := g1.Next()
+	// 模拟重启：新的生成器从新号段继续，不会重复
+	g2, _ := NewSerial("ORD{yyyyMMdd}{seq:6}", WithSerialStore(store, "order", 10))
+	g2.now = func() time.Time { return now }
+	second, _ := g2.Next()
+	if first != "ORD20261018000001" || second != "ORD20261018000011" {
+		t.Errorf("Next() = %s, %s", first, second)
+	}
+}
+
+func TestSerialConcurrent(t *testing.T) {
+	g, _ := NewSerial("ORD{yyyyMMdd}{seq:6}")
+	var serials sync.Map
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				serial, _ := g.Next()
+				if _, loaded := serials.LoadOrStore(serial, struct{}{}); loaded {
+					t.Errorf("duplicate serial %s", serial)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
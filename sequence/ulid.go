@@ -0,0 +1,174 @@
+package sequence
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// crockfordAlphabet Crockford Base32 字符表（不含 I、L、O、U）
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidMaxTime ULID 可表示的最大毫秒时间戳（48位）
+const ulidMaxTime = 1<<48 - 1
+
+// ErrULIDOverflow 同一毫秒内生成的 ULID 过多，随机部分递增溢出
+var ErrULIDOverflow = errors.New("ulid entropy overflow in current millisecond")
+
+// crockfordDecoding Crockford Base32 解码表，兼容小写及 I、L、O 的易混淆写法
+var crockfordDecoding = func() [256]byte {
+	var table [256]byte
+	for i := range table {
+		table[i] = 0xFF
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		c := crockfordAlphabet[i]
+		table[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			table[c+'a'-'A'] = byte(i)
+		}
+	}
+	table['I'], table['i'], table['L'], table['l'] = 1, 1, 1, 1
+	table['O'], table['o'] = 0, 0
+	return table
+}()
+
+// ULID 128位按时间排序的唯一标识：48位毫秒时间戳 + 80位随机数
+type ULID [16]byte
+
+// String 返回26位 Crockford Base32 编码
+func (u ULID) String() string {
+	var buf [26]byte
+	// 128位前补2个0位凑成130位，每5位编码为一个字符
+	for i := 0; i < 26; i++ {
+		var v byte
+		for j := 0; j < 5; j++ {
+			bit := i*5 + j - 2
+			v <<= 1
+			if bit >= 0 && u[bit/8]&(0x80>>(bit%8)) != 0 {
+				v |= 1
+			}
+		}
+		buf[i] = crockfordAlphabet[v]
+	}
+	return string(buf[:])
+}
+
+// Timestamp 返回毫秒时间戳
+func (u ULID) Timestamp() int64 {
+	return int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+}
+
+// Time 返回生成时间
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(u.Timestamp())
+}
+
+// ParseULID 解析26位 Crockford Base32 编码的 ULID
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != 26 {
+		return u, fmt.Errorf("invalid ulid length %d, want 26", len(s))
+	}
+	// 首字符只能表示3位，超过 7 时溢出
+	if v := crockfordDecoding[s[0]]; v > 7 {
+		return u, fmt.Errorf("invalid ulid %q", s)
+	}
+	for i := 0; i < 26; i++ {
+		v := crockfordDecoding[s[i]]
+		if v == 0xFF {
+			return u, fmt.Errorf("invalid ulid character %q", s[i])
+		}
+		for j := 0; j < 5; j++ {
+			bit := i*5 + j - 2
+			if bit >= 0 && v&(0x10>>j) != 0 {
+				u[bit/8] |= 0x80 >> (bit % 8)
+			}
+		}
+	}
+	return u, nil
+}
+
+// IsULID 判断字符串是否为合法的 ULID
+func IsULID(s string) bool {
+	_, err := ParseULID(s)
+	return err == nil
+}
+
+// ULIDGenerator ULID 生成器，同一毫秒内生成的 ULID 单调递增，已通过加锁来保证线程安全
+type ULIDGenerator struct {
+	lastTimestamp int64      // 上次生成的毫秒时间戳
+	last          ULID       // 上次生成的 ULID
+	lock          sync.Mutex // 锁
+}
+
+// NewULIDGenerator 创建 ULID 生成器
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{lastTimestamp: -1}
+}
+
+// defaultULIDGenerator 包级默认 ULID 生成器
+var defaultULIDGenerator = NewULIDGenerator()
+
+// NewULID 使用默认生成器生成 ULID
+func NewULID() (ULID, error) {
+	return defaultULIDGenerator.Next()
+}
+
+// Next 生成 ULID，同一毫秒（或时钟回拨）时在上一个 ULID 的随机部分上加1
+func (g *ULIDGenerator) Next() (ULID, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	timestamp := time.Now().UnixMilli()
+	if timestamp > ulidMaxTime {
+		return ULID{}, fmt.Errorf("%w: %d", ErrTimestampOverflow, timestamp)
+	}
+	var u ULID
+	if timestamp <= g.lastTimestamp {
+		u = g.last
+		// 随机部分按80位大端整数加1
+		i := len(u) - 1
+		for ; i >= 6; i-- {
+			u[i]++
+			if u[i] != 0 {
+				break
+			}
+		}
+		if i < 6 {
+			return ULID{}, ErrULIDOverflow
+		}
+	} else {
+		u[0] = byte(timestamp >> 40)
+		u[1] = byte(timestamp >> 32)
+		u[2] = byte(timestamp >> 24)
+		u[3] = byte(timestamp >> 16)
+		u[4] = byte(timestamp >> 8)
+		u[5] = byte(timestamp)
+		if _, err := rand.Read(u[6:]); err != nil {
+			return ULID{}, fmt.Errorf("failed to read random: %w", err)
+		}
+		g.lastTimestamp = timestamp
+	}
+	g.last = u
+	return u, nil
+}
+
+// NextString implements the IDGenerator interface.
+func (g *ULIDGenerator) NextString() (string, error) {
+	u, err := g.Next()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// ParseTime implements the IDGenerator interface.
+func (g *ULIDGenerator) ParseTime(id string) (time.Time, error) {
+	u, err := ParseULID(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return u.Time(), nil
+}
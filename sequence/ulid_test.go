@@ -0,0 +1,59 @@
+package sequence
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseULID(t *testing.T) {
+	u, err := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Timestamp() != 1469922850259 {
+		t.Errorf("Timestamp() = %d, want %d", u.Timestamp(), 1469922850259)
+	}
+	if u.String() != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("String() = %s", u.String())
+	}
+	if lower, _ := ParseULID(strings.ToLower("01ARZ3NDEKTSV4RRFFQ69G5FAV")); lower != u {
+		t.Errorf("ParseULID() should be case insensitive")
+	}
+	tests := []struct {
+		name string
+		s    string
+		want bool
+	}{
+		{"Valid", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"Max", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", true},
+		{"Overflow", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", false},
+		{"Too short", "01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+		{"Invalid character", "01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsULID(tt.s); got != tt.want {
+				t.Errorf("IsULID(%s) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestULIDGenerator(t *testing.T) {
+	g := NewULIDGenerator()
+	last := ""
+	for i := 0; i < 10000; i++ {
+		id, err := g.NextString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id <= last {
+			t.Fatalf("NextString() = %s, want greater than %s", id, last)
+		}
+		last = id
+	}
+	u, _ := ParseULID(last)
+	if parsed, err := g.ParseTime(last); err != nil || !parsed.Equal(u.Time()) {
+		t.Errorf("ParseTime() = %v, %v", parsed, err)
+	}
+}
@@ -0,0 +1,63 @@
+package sequence
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewUUIDv7 生成 UUID version 7，前48位为毫秒时间戳
+func NewUUIDv7() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+// ParseUUIDv7 解析 UUID version 7，支持带或不带连字符的格式
+func ParseUUIDv7(s string) (uuid.UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if u.Version() != 7 || u.Variant() != uuid.RFC4122 {
+		return uuid.Nil, fmt.Errorf("invalid uuid version %d, want 7", u.Version())
+	}
+	return u, nil
+}
+
+// IsUUIDv7 判断字符串是否为合法的 UUID version 7
+func IsUUIDv7(s string) bool {
+	_, err := ParseUUIDv7(s)
+	return err == nil
+}
+
+// UUIDv7Time 返回 UUID version 7 的生成时间
+func UUIDv7Time(u uuid.UUID) time.Time {
+	timestamp := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	return time.UnixMilli(timestamp)
+}
+
+// UUIDv7Generator UUID version 7 生成器
+type UUIDv7Generator struct{}
+
+// NewUUIDv7Generator 创建 UUID version 7 生成器
+func NewUUIDv7Generator() *UUIDv7Generator {
+	return &UUIDv7Generator{}
+}
+
+// NextString implements the IDGenerator interface.
+func (g *UUIDv7Generator) NextString() (string, error) {
+	u, err := NewUUIDv7()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// ParseTime implements the IDGenerator interface.
+func (g *UUIDv7Generator) ParseTime(id string) (time.Time, error) {
+	u, err := ParseUUIDv7(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return UUIDv7Time(u), nil
+}
@@ -0,0 +1,29 @@
+package sequence
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUUIDv7Generator(t *testing.T) {
+	g := NewUUIDv7Generator()
+	id, err := g.NextString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsUUIDv7(id) || !IsUUIDv7(strings.ReplaceAll(id, "-", "")) {
+		t.Errorf("IsUUIDv7(%s) = false", id)
+	}
+	parsed, err := g.ParseTime(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(parsed) > time.Minute {
+		t.Errorf("ParseTime() = %v", parsed)
+	}
+	// UUID version 4 不是合法的 UUID version 7
+	if IsUUIDv7("f47ac10b-58cc-4372-a567-0e02b2c3d479") {
+		t.Errorf("IsUUIDv7() should reject uuid version 4")
+	}
+}
@@ -0,0 +1,287 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkerIdProvider 机器ID提供者，用于自动分配 Sequence 的机房ID与机器ID。
+// 返回的ID取值范围为 [0, maxWorkerId]，其中 maxWorkerId 覆盖机房ID与机器ID的全部位数，
+// 高位作为机房ID，低位作为机器ID
+type WorkerIdProvider interface {
+	WorkerId(maxWorkerId int64) (int64, error)
+}
+
+// WorkerIdProviderFunc 函数形式的 WorkerIdProvider
+type WorkerIdProviderFunc func(maxWorkerId int64) (int64, error)
+
+// WorkerId implements the WorkerIdProvider interface.
+func (f WorkerIdProviderFunc) WorkerId(maxWorkerId int64) (int64, error) {
+	return f(maxWorkerId)
+}
+
+// WorkerIdChecker 可以报告机器ID是否仍然有效的 WorkerIdProvider，如 LeaseWorkerIdProvider。
+// Sequence 在每次生成ID前调用 Err，返回错误时不再生成ID，避免与接管该ID的进程生成重复的ID
+type WorkerIdChecker interface {
+	Err() error
+}
+
+// WithWorkerIdProvider 通过 WorkerIdProvider 自动分配机房ID与机器ID，会覆盖 WithDataCenterId 与 WithWorkerId。
+// provider 实现了 WorkerIdChecker 时，机器ID失效后 Generate 等方法返回 ErrWorkerIdLost
+func WithWorkerIdProvider(provider WorkerIdProvider) Option {
+	return func(s *Sequence) {
+		s.workerIdProvider = provider
+	}
+}
+
+// HostWorkerIdProvider 根据主机名与IP地址的哈希值分配机器ID。
+// 不同主机之间可能发生哈希冲突，同一主机的多个进程会得到相同的ID
+func HostWorkerIdProvider() WorkerIdProvider {
+	return WorkerIdProviderFunc(func(maxWorkerId int64) (int64, error) {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get hostname: %w", err)
+		}
+		h := fnv.New64a()
+		h.Write([]byte(hostname))
+		if addrs, err := net.InterfaceAddrs(); err == nil {
+			for _, addr := range addrs {
+				if ip, ok := addr.(*net.IPNet); ok && !ip.IP.IsLoopback() {
+					h.Write(ip.IP)
+				}
+			}
+		}
+		return int64(h.Sum64() % uint64(maxWorkerId+1)), nil
+	})
+}
+
+// EnvWorkerIdProvider 从环境变量读取机器ID
+func EnvWorkerIdProvider(key string) WorkerIdProvider {
+	return WorkerIdProviderFunc(func(maxWorkerId int64) (int64, error) {
+		value, found := os.LookupEnv(key)
+		if !found {
+			return 0, fmt.Errorf("environment variable %s is not set", key)
+		}
+		workerId, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("environment variable %s is not a number: %w", key, err)
+		}
+		if workerId < 0 || workerId > maxWorkerId {
+			return 0, fmt.Errorf("environment variable %s can't be greater than %d or less than 0", key, maxWorkerId)
+		}
+		return workerId, nil
+	})
+}
+
+var (
+	// errLeaseReleased 租约已释放
+	errLeaseReleased = errors.New("lease released")
+	// errLeaseLost 租约文件已被删除或被其他进程接管
+	errLeaseLost = errors.New("lease lost")
+)
+
+// LeaseWorkerIdProvider 基于共享目录中租约文件分配机器ID，保证同一目录下的进程不会得到相同的ID。
+// 每个ID对应一个租约文件，通过目录锁文件保证分配与续约的互斥；获得租约后在后台定期续约，
+// 超过有效期未续约的租约可被其他进程回收。实现了 WorkerIdChecker，租约丢失或超过有效期未续约时
+// 使用该提供者的 Sequence 会停止生成ID
+type LeaseWorkerIdProvider struct {
+	dir      string        // 租约目录
+	ttl      time.Duration // 租约有效期
+	token    string        // 本进程的租约标识
+	workerId int64         // 已获得的机器ID
+	file     string        // 已获得的租约文件
+	err      error         // 续约失败的错误
+	failed   atomic.Bool   // 是否续约失败或已释放，用于快速判断
+	renewed  atomic.Int64  // 最后一次成功获得或续约租约的时间（纳秒）
+	stop     chan struct{} // 停止续约
+	lock     sync.Mutex    // 锁
+}
+
+// MinLeaseTTL 租约有效期的最小值，过短的有效期会导致频繁续约，且来不及续约时租约会被其他进程回收
+const MinLeaseTTL = time.Second
+
+// NewLeaseWorkerIdProvider 创建租约文件机器ID提供者，ttl 小于 MinLeaseTTL 时返回错误
+// @dir 租约目录，多个进程需使用同一目录
+// @ttl 租约有效期，每 ttl/3 续约一次
+func NewLeaseWorkerIdProvider(dir string, ttl time.Duration) (*LeaseWorkerIdProvider, error) {
+	if ttl < MinLeaseTTL {
+		return nil, fmt.Errorf("lease ttl %s is less than %s", ttl, MinLeaseTTL)
+	}
+	return &LeaseWorkerIdProvider{
+		dir:      dir,
+		ttl:      ttl,
+		token:    uuid.NewString(),
+		workerId: -1,
+	}, nil
+}
+
+// WorkerId 获取一个未被占用或已过期的机器ID并开始后台续约，重复调用返回已获得的ID
+func (p *LeaseWorkerIdProvider) WorkerId(maxWorkerId int64) (int64, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.workerId >= 0 {
+		return p.workerId, nil
+	}
+	if err := os.MkdirAll(p.dir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create lease directory %s: %w", p.dir, err)
+	}
+	// 通过目录锁文件保证分配互斥
+	unlock, err := lockFile(filepath.Join(p.dir, "worker.lock"), 2*p.ttl)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	for id := int64(0); id <= maxWorkerId; id++ {
+		file := filepath.Join(p.dir, fmt.Sprintf("worker-%d.lease", id))
+		if info, err := os.Stat(file); err == nil {
+			if time.Since(info.ModTime()) < p.ttl {
+				continue
+			}
+			// 租约已过期，回收
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return 0, fmt.Errorf("failed to remove expired lease %s: %w", file, err)
+			}
+		}
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			if os.IsExist(err) {
+				continue
+			}
+			return 0, fmt.Errorf("failed to create lease %s: %w", file, err)
+		}
+		_, err = f.WriteString(p.token)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(file)
+			return 0, fmt.Errorf("failed to write lease %s: %w", file, err)
+		}
+		p.workerId = id
+		p.file = file
+		p.err = nil
+		p.failed.Store(false)
+		p.renewed.Store(time.Now().UnixNano())
+		p.stop = make(chan struct{})
+		go p.renewLoop(p.stop)
+		return id, nil
+	}
+	return 0, fmt.Errorf("no available worker id in %s, all %d leases are in use", p.dir, maxWorkerId+1)
+}
+
+// Renew 续约当前租约，租约已被其他进程回收时返回错误，此后 Err 也返回该错误
+func (p *LeaseWorkerIdProvider) Renew() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	err := p.renew()
+	if errors.Is(err, errLeaseLost) {
+		p.err = err
+		p.failed.Store(true)
+	}
+	return err
+}
+
+// renew 在目录锁内确认租约仍属于本进程并刷新修改时间，避免刷新其他进程刚接管的租约
+func (p *LeaseWorkerIdProvider) renew() error {
+	if p.workerId < 0 {
+		return errors.New("lease not acquired")
+	}
+	unlock, err := lockFile(filepath.Join(p.dir, "worker.lock"), p.ttl/3)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	token, err := os.ReadFile(p.file)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: lease %s was removed", errLeaseLost, p.file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read lease %s: %w", p.file, err)
+	}
+	if string(token) != p.token {
+		return fmt.Errorf("%w: lease %s is held by another process", errLeaseLost, p.file)
+	}
+	now := time.Now()
+	if err := os.Chtimes(p.file, now, now); err != nil {
+		return fmt.Errorf("failed to renew lease %s: %w", p.file, err)
+	}
+	p.renewed.Store(now.UnixNano())
+	return nil
+}
+
+// Release 停止续约并释放租约
+func (p *LeaseWorkerIdProvider) Release() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.workerId < 0 {
+		return nil
+	}
+	close(p.stop)
+	token, err := os.ReadFile(p.file)
+	if err == nil && string(token) == p.token {
+		err = os.Remove(p.file)
+	}
+	p.workerId = -1
+	p.file = ""
+	p.err = errLeaseReleased
+	p.failed.Store(true)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	return nil
+}
+
+// Err 返回租约失效的原因：后台续约失败、租约已被其他进程接管、已释放，或超过有效期未成功续约
+// （如进程暂停超过有效期，此时其他进程可能已回收该ID）。租约有效时返回 nil
+func (p *LeaseWorkerIdProvider) Err() error {
+	if p.failed.Load() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		return p.err
+	}
+	renewed := p.renewed.Load()
+	if renewed == 0 {
+		return nil
+	}
+	if elapsed := time.Since(time.Unix(0, renewed)); elapsed >= p.ttl {
+		return fmt.Errorf("lease not renewed for %s, exceeds ttl %s", elapsed.Round(time.Millisecond), p.ttl)
+	}
+	return nil
+}
+
+// renewLoop 后台定期续约，租约丢失后停止
+func (p *LeaseWorkerIdProvider) renewLoop(stop chan struct{}) {
+	ticker := time.NewTicker(p.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// 租约丢失后停止续约；其他错误（如等待目录锁超时）在下次继续重试，
+			// 持续失败超过有效期时由 Err 报告
+			p.lock.Lock()
+			err := p.renew()
+			lost := errors.Is(err, errLeaseLost)
+			if lost {
+				p.err = err
+				p.failed.Store(true)
+			}
+			p.lock.Unlock()
+			if lost {
+				return
+			}
+		}
+	}
+}
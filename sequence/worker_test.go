@@ -0,0 +1,183 @@
+package sequence
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEnvWorkerIdProvider(t *testing.T) {
+	t.Setenv("SEQUENCE_WORKER_ID", "37")
+	s, err := NewWithOptions(WithWorkerIdProvider(EnvWorkerIdProvider("SEQUENCE_WORKER_ID")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := s.Decompose(s.NextId())
+	if parts.DataCenterId != 1 || parts.WorkerId != 5 {
+		t.Errorf("Decompose() = %+v, want dataCenterId 1 workerId 5", parts)
+	}
+
+	t.Setenv("SEQUENCE_WORKER_ID", "1024")
+	if _, err := NewWithOptions(WithWorkerIdProvider(EnvWorkerIdProvider("SEQUENCE_WORKER_ID"))); err == nil {
+		t.Errorf("NewWithOptions() should fail when worker id is out of range")
+	}
+	if _, err := EnvWorkerIdProvider("SEQUENCE_WORKER_ID_NOT_SET").WorkerId(1023); err == nil {
+		t.Errorf("WorkerId() should fail when environment variable is not set")
+	}
+}
+
+func TestHostWorkerIdProvider(t *testing.T) {
+	provider := HostWorkerIdProvider()
+	id1, err := provider.WorkerId(1023)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, _ := provider.WorkerId(1023)
+	if id1 != id2 || id1 < 0 || id1 > 1023 {
+		t.Errorf("WorkerId() = %d, %d", id1, id2)
+	}
+}
+
+func TestLeaseWorkerIdProvider(t *testing.T) {
+	dir := t.TempDir()
+	p1 := newLeaseProvider(t, dir, time.Second)
+	p2 := newLeaseProvider(t, dir, time.Second)
+	id1, err := p1.WorkerId(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id2, err := p2.WorkerId(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id1 == id2 {
+		t.Fatalf("WorkerId() got the same id %d", id1)
+	}
+	p3 := newLeaseProvider(t, dir, time.Second)
+	if _, err := p3.WorkerId(1); err == nil {
+		t.Errorf("WorkerId() should fail when all leases are in use")
+	}
+	// 续约后租约仍然有效
+	time.Sleep(700 * time.Millisecond)
+	if err := p1.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+	if _, err := p3.WorkerId(1); err == nil {
+		t.Errorf("WorkerId() should fail when leases are renewed")
+	}
+	// 释放后可被其他进程获取
+	if err := p1.Release(); err != nil {
+		t.Fatal(err)
+	}
+	id3, err := p3.WorkerId(1)
+	if err != nil || id3 != id1 {
+		t.Errorf("WorkerId() = %d, %v, want %d", id3, err, id1)
+	}
+	_ = p2.Release()
+	_ = p3.Release()
+}
+
+func TestLeaseWorkerIdProviderExpired(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "worker-0.lease")
+	if err := os.WriteFile(file, []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expired := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(file, expired, expired); err != nil {
+		t.Fatal(err)
+	}
+	p := newLeaseProvider(t, dir, time.Second)
+	defer p.Release()
+	s, err := NewWithOptions(WithWorkerIdProvider(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parts := s.Decompose(s.NextId()); parts.DataCenterId != 0 || parts.WorkerId != 0 {
+		t.Errorf("Decompose() = %+v, want expired lease 0", parts)
+	}
+}
+
+func TestLeaseWorkerIdProviderLost(t *testing.T) {
+	p := newLeaseProvider(t, t.TempDir(), time.Minute)
+	defer p.Release()
+	s, err := NewWithOptions(WithWorkerIdProvider(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	// 模拟租约过期后被其他进程接管
+	if err := os.WriteFile(p.file, []byte("other"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Renew(); err == nil {
+		t.Fatal("Renew() error = nil, want lease held by another process")
+	}
+	if _, err := s.Generate(); !errors.Is(err, ErrWorkerIdLost) {
+		t.Errorf("Generate() error = %v, want ErrWorkerIdLost", err)
+	}
+	if _, err := s.TryNextId(); !errors.Is(err, ErrWorkerIdLost) {
+		t.Errorf("TryNextId() error = %v, want ErrWorkerIdLost", err)
+	}
+	if _, err := s.NextIds(2); !errors.Is(err, ErrWorkerIdLost) {
+		t.Errorf("NextIds() error = %v, want ErrWorkerIdLost", err)
+	}
+}
+
+func TestLeaseWorkerIdProviderReleased(t *testing.T) {
+	p := newLeaseProvider(t, t.TempDir(), time.Minute)
+	s, err := NewWithOptions(WithWorkerIdProvider(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Generate(); !errors.Is(err, ErrWorkerIdLost) {
+		t.Errorf("Generate() error = %v, want ErrWorkerIdLost", err)
+	}
+}
+
+func TestLeaseWorkerIdProviderNotRenewed(t *testing.T) {
+	p := newLeaseProvider(t, t.TempDir(), time.Minute)
+	defer p.Release()
+	s, err := NewWithOptions(WithWorkerIdProvider(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 模拟进程暂停超过有效期未能续约
+	p.renewed.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	if err := p.Err(); err == nil {
+		t.Error("Err() = nil, want lease not renewed")
+	}
+	if _, err := s.Generate(); !errors.Is(err, ErrWorkerIdLost) {
+		t.Errorf("Generate() error = %v, want ErrWorkerIdLost", err)
+	}
+	if err := p.Renew(); err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+	if _, err := s.Generate(); err != nil {
+		t.Errorf("Generate() error = %v after renew", err)
+	}
+}
+
+func newLeaseProvider(t *testing.T, dir string, ttl time.Duration) *LeaseWorkerIdProvider {
+	t.Helper()
+	p, err := NewLeaseWorkerIdProvider(dir, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLeaseWorkerIdProviderTTL(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Second, time.Nanosecond, 999 * time.Millisecond} {
+		if _, err := NewLeaseWorkerIdProvider(t.TempDir(), ttl); err == nil {
+			t.Errorf("NewLeaseWorkerIdProvider(ttl=%s) error = nil", ttl)
+		}
+	}
+}
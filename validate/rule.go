@@ -0,0 +1,138 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/minlib/go-util/check"
+)
+
+// DefaultCode 校验失败的默认错误码
+const DefaultCode = 400
+
+// Func 校验函数，value 为已解引用的字段值，param 为规则的参数，如 min=1 中的 1
+type Func func(value reflect.Value, param string) bool
+
+// Rule 命名的校验规则
+type Rule struct {
+	Name    string // 规则名称，即标签中使用的名称
+	Code    int    // 校验失败时的错误码，为 0 时使用 DefaultCode
+	Message string // 校验失败时的提示信息，{field} 替换为字段路径，{param} 替换为规则参数
+	Func    Func   // 校验函数
+}
+
+var (
+	rules    = make(map[string]Rule)
+	rulesMux sync.RWMutex
+)
+
+// Register 注册校验规则，已存在的同名规则会被覆盖，可用于修改内置规则的错误码与提示信息
+func Register(rule Rule) {
+	if rule.Name == "" || rule.Func == nil {
+		panic("validate: rule name and func can't be empty")
+	}
+	if rule.Code == 0 {
+		rule.Code = DefaultCode
+	}
+	rulesMux.Lock()
+	defer rulesMux.Unlock()
+	rules[rule.Name] = rule
+}
+
+// RegisterString 注册字符串校验规则，非字符串类型的字段校验失败
+// @name 规则名称
+// @message 校验失败时的提示信息
+// @fn 校验函数，如 check.CheckMobile
+func RegisterString(name, message string, fn func(string) bool) {
+	Register(Rule{
+		Name:    name,
+		Message: message,
+		Func: func(value reflect.Value, _ string) bool {
+			return value.Kind() == reflect.String && fn(value.String())
+		},
+	})
+}
+
+// lookupRule 查找校验规则
+func lookupRule(name string) (Rule, bool) {
+	rulesMux.RLock()
+	defer rulesMux.RUnlock()
+	rule, found := rules[name]
+	return rule, found
+}
+
+func init() {
+	Register(Rule{Name: "required", Message: "{field} is required", Func: required})
+	Register(Rule{Name: "min", Message: "{field} must be at least {param}", Func: compare(func(v, p float64) bool { return v >= p })})
+	Register(Rule{Name: "max", Message: "{field} must be at most {param}", Func: compare(func(v, p float64) bool { return v <= p })})
+	Register(Rule{Name: "len", Message: "{field} length must be {param}", Func: compare(func(v, p float64) bool { return v == p })})
+	Register(Rule{Name: "oneof", Message: "{field} must be one of [{param}]", Func: oneOf})
+	RegisterString("mobile", "{field} is not a valid mobile number", check.CheckMobile)
+	RegisterString("idcard", "{field} is not a valid id card number", check.CheckIdCard)
+	RegisterString("username", "{field} is not a valid user name", check.CheckUserName)
+	RegisterString("password", "{field} is not a valid password", check.CheckPassword)
+	RegisterString("email", "{field} is not a valid email", check.CheckEmail)
+	RegisterString("creditcode", "{field} is not a valid unified social credit code", check.CheckCreditCode)
+	RegisterString("bankcard", "{field} is not a valid bank card number", check.CheckBankCard)
+	RegisterString("passport", "{field} is not a valid passport number", check.CheckPassport)
+	RegisterString("plate", "{field} is not a valid license plate", check.CheckLicensePlate)
+	RegisterString("postcode", "{field} is not a valid postal code", check.CheckPostalCode)
+	RegisterString("landline", "{field} is not a valid landline number", check.CheckLandline)
+}
+
+// required 值不能为零值，指针不能为 nil，字符串、切片与映射不能为空，结构体的字段由其自身的规则校验
+func required(value reflect.Value, _ string) bool {
+	if !value.IsValid() {
+		return false
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() > 0
+	case reflect.Struct:
+		return true
+	default:
+		return !value.IsZero()
+	}
+}
+
+// compare 比较数值的大小、字符串的字符数或切片、数组与映射的长度
+func compare(fn func(v, p float64) bool) Func {
+	return func(value reflect.Value, param string) bool {
+		p, err := strconv.ParseFloat(param, 64)
+		if err != nil || !value.IsValid() {
+			return false
+		}
+		switch value.Kind() {
+		case reflect.String:
+			return fn(float64(utf8.RuneCountInString(value.String())), p)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fn(float64(value.Len()), p)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return fn(float64(value.Int()), p)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return fn(float64(value.Uint()), p)
+		case reflect.Float32, reflect.Float64:
+			return fn(value.Float(), p)
+		default:
+			return false
+		}
+	}
+}
+
+// oneOf 值必须是以空格分隔的参数之一，如 oneof=male female
+func oneOf(value reflect.Value, param string) bool {
+	if !value.IsValid() {
+		return false
+	}
+	s := fmt.Sprint(value.Interface())
+	for _, option := range strings.Fields(param) {
+		if s == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+// Package validate 基于结构体标签的声明式校验，如 `validate:"required,min=1,max=32,mobile"`。
+// 校验会递归进入嵌套的结构体、指针、切片、数组与映射，字段路径优先使用 json 标签中的名称
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/minlib/go-util/errorx"
+)
+
+// tagName 校验规则使用的标签名称
+const tagName = "validate"
+
+// FieldError 字段的校验错误，Code 与 Message 可直接转为 errorx.Error
+type FieldError struct {
+	Field   string `json:"field"`           // 字段路径，如 items[0].name
+	Rule    string `json:"rule"`            // 校验失败的规则
+	Param   string `json:"param,omitempty"` // 规则的参数
+	Code    int    `json:"code"`            // 错误码
+	Message string `json:"message"`         // 提示信息
+}
+
+// Error 返回提示信息
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// AsError 转为 errorx.Error，字段、规则与参数保存在 Details 中
+func (e *FieldError) AsError() *errorx.Error {
+	details := map[string]any{"field": e.Field, "rule": e.Rule}
+	if e.Param != "" {
+		details["param"] = e.Param
+	}
+	return errorx.New(e.Code, e.Message).WithDetails(details)
+}
+
+// Errors 所有字段的校验错误
+type Errors []*FieldError
+
+// Error 返回所有提示信息，以分号分隔
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// AsError 将第一个字段的错误转为 errorx.Error，所有字段的错误保存在 Details 的 fields 中，没有错误时返回 nil
+func (e Errors) AsError() *errorx.Error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e[0].AsError().WithDetail("fields", []*FieldError(e))
+}
+
+// Struct 校验结构体，全部通过时返回 nil，否则返回 Errors。未注册的规则返回普通错误
+// @v 结构体或其指针，也可以是结构体的切片
+func Struct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return errors.New("validate value invalid")
+	}
+	w := &walker{visited: make(map[uintptr]struct{})}
+	if err := w.walk("", value); err != nil {
+		return err
+	}
+	if len(w.errors) > 0 {
+		return w.errors
+	}
+	return nil
+}
+
+// fieldRules 字段的校验规则
+type fieldRules struct {
+	index     int      // 字段索引
+	name      string   // 字段在路径中的名称
+	embedded  bool     // 是否为展开的嵌入结构体
+	omitEmpty bool     // 零值时跳过校验
+	rules     []string // 规则名称
+	params    []string // 规则参数
+}
+
+// rulesCache 已解析的结构体校验规则
+var rulesCache sync.Map
+
+// loadRules 解析并缓存结构体各字段的校验规则
+func loadRules(t reflect.Type) ([]fieldRules, error) {
+	if cached, found := rulesCache.Load(t); found {
+		return cached.([]fieldRules), nil
+	}
+	var fields []fieldRules
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(tagName)
+		if tag == "-" || !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+		f := fieldRules{index: i, name: name}
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			f.embedded = true
+		}
+		for _, part := range strings.Split(tag, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if part == "omitempty" {
+				f.omitEmpty = true
+				continue
+			}
+			rule, param, _ := strings.Cut(part, "=")
+			if _, found := lookupRule(rule); !found {
+				return nil, fmt.Errorf("unknown validate rule %q on %s.%s", rule, t, field.Name)
+			}
+			f.rules = append(f.rules, rule)
+			f.params = append(f.params, param)
+		}
+		fields = append(fields, f)
+	}
+	actual, _ := rulesCache.LoadOrStore(t, fields)
+	return actual.([]fieldRules), nil
+}
+
+// walker 一次校验的上下文
+type walker struct {
+	errors  Errors               // 已发现的错误
+	visited map[uintptr]struct{} // 当前路径上的指针，用于处理循环引用
+}
+
+// walk 递归校验值中嵌套的结构体
+func (w *walker) walk(path string, value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		ptr := value.Pointer()
+		if _, found := w.visited[ptr]; found {
+			return nil
+		}
+		w.visited[ptr] = struct{}{}
+		defer delete(w.visited, ptr)
+		return w.walk(path, value.Elem())
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return w.walk(path, value.Elem())
+	case reflect.Struct:
+		return w.walkStruct(path, value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := w.walk(path+"["+strconv.Itoa(i)+"]", value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			if err := w.walk(fmt.Sprintf("%s[%v]", path, iter.Key().Interface()), iter.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkStruct 校验结构体的字段，再递归校验字段中嵌套的结构体
+func (w *walker) walkStruct(path string, value reflect.Value) error {
+	fields, err := loadRules(value.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		fieldValue := value.Field(f.index)
+		fieldPath := path
+		if !f.embedded {
+			fieldPath = joinPath(path, f.name)
+		}
+		if f.omitEmpty && isEmpty(fieldValue) {
+			continue
+		}
+		if !w.check(fieldPath, fieldValue, f) {
+			continue
+		}
+		if err := w.walk(fieldPath, fieldValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check 按顺序执行字段的规则，遇到第一个失败的规则时记录错误并返回 false
+func (w *walker) check(path string, value reflect.Value, f fieldRules) bool {
+	indirect := value
+	for indirect.Kind() == reflect.Ptr || indirect.Kind() == reflect.Interface {
+		if indirect.IsNil() {
+			indirect = reflect.Value{}
+			break
+		}
+		indirect = indirect.Elem()
+	}
+	for i, name := range f.rules {
+		// nil 指针视为未填写，只校验 required
+		if !indirect.IsValid() && name != "required" {
+			continue
+		}
+		rule, _ := lookupRule(name)
+		if rule.Func(indirect, f.params[i]) {
+			continue
+		}
+		message := strings.NewReplacer("{field}", path, "{param}", f.params[i]).Replace(rule.Message)
+		w.errors = append(w.errors, &FieldError{
+			Field:   path,
+			Rule:    name,
+			Param:   f.params[i],
+			Code:    rule.Code,
+			Message: message,
+		})
+		return false
+	}
+	return true
+}
+
+// isEmpty 判断值是否为零值，nil 指针、空字符串、空切片与空映射均视为零值
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+// joinPath 拼接字段路径
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,139 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Address struct {
+	City   string `json:"city" validate:"required,max=8"`
+	Street string `json:"street"`
+}
+
+type Item struct {
+	Name  string `json:"name" validate:"required"`
+	Count int    `json:"count" validate:"min=1,max=99"`
+}
+
+type Base struct {
+	Id int64 `validate:"min=1"`
+}
+
+type User struct {
+	Base
+	Name     string            `json:"name" validate:"required,min=2,max=8"`
+	Mobile   string            `json:"mobile" validate:"required,mobile"`
+	Email    string            `json:"email,omitempty" validate:"omitempty,email"`
+	Gender   string            `json:"gender" validate:"oneof=male female"`
+	Age      *int              `json:"age" validate:"required,max=150"`
+	Tags     []string          `json:"tags" validate:"max=2"`
+	Address  *Address          `json:"address" validate:"required"`
+	Items    []Item            `json:"items" validate:"required"`
+	Extras   map[string]Item   `json:"extras"`
+	Password string            `json:"-" validate:"-"`
+	Others   map[string]string `json:"others"`
+	Level    *int              `json:"level" validate:"min=1"`
+}
+
+func newUser() User {
+	age := 18
+	return User{
+		Base:    Base{Id: 1},
+		Name:    "admin",
+		Mobile:  "13800138000",
+		Gender:  "male",
+		Age:     &age,
+		Address: &Address{City: "Shenzhen"},
+		Items:   []Item{{Name: "apple", Count: 1}},
+	}
+}
+
+func TestStruct(t *testing.T) {
+	user := newUser()
+	if err := Struct(user); err != nil {
+		t.Errorf("Struct(valid) = %v", err)
+	}
+	if err := Struct(&user); err != nil {
+		t.Errorf("Struct(valid pointer) = %v", err)
+	}
+
+	user.Id = 0
+	user.Name = "管"
+	user.Mobile = "123"
+	user.Email = "admin"
+	user.Gender = "unknown"
+	user.Age = nil
+	user.Tags = []string{"a", "b", "c"}
+	user.Address.City = ""
+	user.Items = []Item{{Name: "apple", Count: 1}, {Count: 100}}
+	user.Extras = map[string]Item{"gift": {Name: "card"}}
+	err := Struct(user)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Struct() error = %v, want Errors", err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Field+":"+e.Rule)
+	}
+	want := []string{
+		"Id:min", "name:min", "mobile:mobile", "email:email", "gender:oneof", "age:required", "tags:max",
+		"address.city:required", "items[1].name:required", "items[1].count:max", "extras[gift].count:min",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Struct() errors = %v\nwant %v", got, want)
+	}
+	if errs[1].Message != "name must be at least 2" {
+		t.Errorf("Message = %q", errs[1].Message)
+	}
+	if e := errs.AsError(); e.Code != DefaultCode || e.Message != "Id must be at least 1" {
+		t.Errorf("AsError() = %+v", e)
+	}
+}
+
+func TestStructSlice(t *testing.T) {
+	err := Struct([]Item{{Name: "a", Count: 1}, {Count: 1}})
+	if err == nil || !strings.Contains(err.Error(), "[1].name is required") {
+		t.Errorf("Struct(slice) error = %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	type Product struct {
+		Code string `json:"code" validate:"required,sku"`
+	}
+	if err := Struct(Product{Code: "A1"}); err == nil || errors.As(err, new(Errors)) {
+		t.Errorf("Struct(unknown rule) error = %v, want unknown rule error", err)
+	}
+	RegisterString("sku", "{field} is not a valid sku", func(s string) bool {
+		return strings.HasPrefix(s, "SKU")
+	})
+	Register(Rule{Name: "required", Code: 1001, Message: "{field} can't be empty", Func: required})
+	defer Register(Rule{Name: "required", Message: "{field} is required", Func: required})
+
+	if err := Struct(Product{Code: "SKU001"}); err != nil {
+		t.Errorf("Struct(valid sku) = %v", err)
+	}
+	var errs Errors
+	if err := Struct(Product{}); !errors.As(err, &errs) || errs[0].Code != 1001 || errs[0].Message != "code can't be empty" {
+		t.Errorf("Struct(empty) = %v", err)
+	}
+	if err := Struct(Product{Code: "A1"}); !errors.As(err, &errs) || errs[0].Rule != "sku" || errs[0].Code != DefaultCode {
+		t.Errorf("Struct(invalid sku) = %v", err)
+	}
+}
+
+func TestStructCycle(t *testing.T) {
+	type Node struct {
+		Name string `validate:"required"`
+		Next *Node
+	}
+	node := &Node{Name: "a"}
+	node.Next = &Node{Next: node}
+	var errs Errors
+	if err := Struct(node); !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "Next.Name" {
+		t.Errorf("Struct(cycle) = %v", err)
+	}
+}